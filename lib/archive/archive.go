@@ -0,0 +1,211 @@
+package archive
+
+import (
+	"fmt"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/imageproxy"
+	"golang.org/x/net/context"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// CameraHealth tracks how recently a traffic camera's image has changed
+type CameraHealth struct {
+	CameraID int `json:"camera_id"`
+
+	// MD5 and Timestamp of the latest image seen
+	MD5       string `json:"md5"`
+	Timestamp string `json:"timestamp"`
+
+	// LastChangedAt is the timestamp of the image when its md5 last changed
+	LastChangedAt string `json:"last_changed_at"`
+
+	// UnchangedPolls is the number of consecutive polls in which the md5 stayed the same
+	UnchangedPolls int `json:"unchanged_polls"`
+
+	// Lag is how far behind the poll's timestamp the image's timestamp was
+	Lag time.Duration `json:"lag"`
+
+	// IsStale is set when the image has not changed for Archive.StaleAfterPolls polls, or lags by more than Archive.MaxLag
+	IsStale bool `json:"is_stale"`
+}
+
+// Archive stores every traffic camera image seen on each traffic images poll, and detects stuck cameras
+type Archive struct {
+	// Dir is where images are stored, as <Dir>/<camera_id>/<timestamp>.jpg. Images are not stored if Dir is empty.
+	Dir string
+
+	// StaleAfterPolls is the number of consecutive polls with an unchanged md5 after which a camera is stale
+	StaleAfterPolls int
+
+	// MaxLag is how far a camera's timestamp may lag behind the poll before the camera is stale
+	MaxLag time.Duration
+
+	// Workers is the number of images downloaded at once
+	Workers int
+
+	HTTPClient *http.Client
+
+	lock    sync.RWMutex
+	cameras map[int]*CameraHealth
+
+	// archived is the md5 of the latest image stored for each camera
+	archived map[int]string
+}
+
+// New returns a new Archive storing images in dir
+func New(dir string) *Archive {
+	return &Archive{
+		Dir:             dir,
+		StaleAfterPolls: 5,
+		MaxLag:          15 * time.Minute,
+		Workers:         4,
+		HTTPClient:      &http.Client{Timeout: 30 * time.Second},
+		cameras:         map[int]*CameraHealth{},
+		archived:        map[int]string{},
+	}
+}
+
+// GetArchiveFromContext returns the archive stored in the context, or nil if there is none
+func GetArchiveFromContext(ctx context.Context) *Archive {
+	if a, ok := ctx.Value("archive").(*Archive); ok {
+		return a
+	}
+	return nil
+}
+
+// Watch registers the archive with a poller, to be updated on every traffic images poll
+func (a *Archive) Watch(p *datagovsg.Poller, interval time.Duration) {
+	p.Watch(
+		"https://api.data.gov.sg/v1/transport/traffic-images",
+		interval,
+		func() interface{} { return &datagovsg.TrafficImagesResult{} },
+		func(res datagovsg.ClientResult) {
+			if res.Err != nil {
+				log.Println("archive: polling traffic images:", res.Err)
+				return
+			}
+			resp, _ := res.Body.(*datagovsg.TrafficImagesResult)
+			a.Record(resp)
+		},
+	)
+}
+
+// Record updates camera health from a traffic images result and stores any images not archived yet.
+// Images that failed to be stored are tried again on the next Record.
+func (a *Archive) Record(resp *datagovsg.TrafficImagesResult) {
+	if resp == nil || len(resp.Items) == 0 {
+		return
+	}
+	item := resp.Items[len(resp.Items)-1]
+	polledAt, _ := datagovsg.ParseTimestamp(item.Timestamp)
+
+	unarchived := []datagovsg.TrafficImageCamera{}
+	for _, camera := range item.Cameras {
+		if a.update(camera, polledAt) && a.Dir != "" {
+			unarchived = append(unarchived, camera)
+		}
+	}
+	a.storeAll(unarchived)
+}
+
+// storeAll stores camera images using up to Workers concurrent downloads, and returns once all are done
+func (a *Archive) storeAll(cameras []datagovsg.TrafficImageCamera) {
+	workers := a.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	queue := make(chan datagovsg.TrafficImageCamera)
+	var wg sync.WaitGroup
+	for i := 0; i < workers && i < len(cameras); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for camera := range queue {
+				if err := a.store(camera); err != nil {
+					log.Println("archive: camera", camera.CameraID, err)
+					continue
+				}
+				a.lock.Lock()
+				a.archived[camera.CameraID] = camera.ImageMetadata.MD5
+				a.lock.Unlock()
+			}
+		}()
+	}
+	for _, camera := range cameras {
+		queue <- camera
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// update records a camera's latest image and returns true if it has not been archived yet
+func (a *Archive) update(camera datagovsg.TrafficImageCamera, polledAt time.Time) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	health, ok := a.cameras[camera.CameraID]
+	if !ok {
+		health = &CameraHealth{CameraID: camera.CameraID}
+		a.cameras[camera.CameraID] = health
+	}
+	if health.MD5 != camera.ImageMetadata.MD5 {
+		health.MD5 = camera.ImageMetadata.MD5
+		health.LastChangedAt = camera.Timestamp
+		health.UnchangedPolls = 0
+	} else {
+		health.UnchangedPolls++
+	}
+	health.Timestamp = camera.Timestamp
+	health.Lag = 0
+	if t, err := datagovsg.ParseTimestamp(camera.Timestamp); err == nil && !polledAt.IsZero() {
+		health.Lag = polledAt.Sub(t)
+	}
+
+	wasStale := health.IsStale
+	health.IsStale = health.UnchangedPolls >= a.StaleAfterPolls || health.Lag > a.MaxLag
+	if health.IsStale && !wasStale {
+		log.Printf("archive: camera %v is stale (unchanged for %v polls, lagging by %v)", camera.CameraID, health.UnchangedPolls, health.Lag)
+	}
+	return a.archived[camera.CameraID] != camera.ImageMetadata.MD5
+}
+
+// store downloads a camera image, verifying its md5, and writes it to <Dir>/<camera_id>/<timestamp>.jpg
+func (a *Archive) store(camera datagovsg.TrafficImageCamera) error {
+	t, err := datagovsg.ParseTimestamp(camera.Timestamp)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(a.Dir, fmt.Sprint(camera.CameraID))
+	path := filepath.Join(dir, t.In(datagovsg.SGT).Format("20060102T150405")+".jpg")
+	if _, err := os.Stat(path); err == nil {
+		// already archived, e.g. before a restart
+		return nil
+	}
+
+	data, err := imageproxy.Download(a.HTTPClient, camera.Image, camera.ImageMetadata.MD5)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// Health returns the health of a camera, or nil if the camera has not been seen yet
+func (a *Archive) Health(cameraID int) *CameraHealth {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	health, ok := a.cameras[cameraID]
+	if !ok {
+		return nil
+	}
+	h := *health
+	return &h
+}
@@ -0,0 +1,172 @@
+package archive_test
+
+import (
+	"fmt"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/archive"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func poll(timestamp string, cameraTimestamp string, md5 string) *datagovsg.TrafficImagesResult {
+	camera := datagovsg.TrafficImageCamera{
+		Timestamp: cameraTimestamp,
+		CameraID:  1001,
+	}
+	camera.ImageMetadata.MD5 = md5
+	return &datagovsg.TrafficImagesResult{
+		Items: []datagovsg.TrafficImagesResultItem{
+			{Timestamp: timestamp, Cameras: []datagovsg.TrafficImageCamera{camera}},
+		},
+	}
+}
+
+func TestArchive_Record(t *testing.T) {
+	a := archive.New("")
+	a.StaleAfterPolls = 2
+
+	if a.Health(1001) != nil {
+		t.Fatal("expected no health before the first poll")
+	}
+
+	a.Record(poll("2016-12-01T10:00:00+08:00", "2016-12-01T10:00:00+08:00", "a"))
+	a.Record(poll("2016-12-01T10:01:00+08:00", "2016-12-01T10:01:00+08:00", "b"))
+	health := a.Health(1001)
+	if health.IsStale || health.LastChangedAt != "2016-12-01T10:01:00+08:00" {
+		t.Fatalf("unexpected health after change: %+v", health)
+	}
+
+	a.Record(poll("2016-12-01T10:02:00+08:00", "2016-12-01T10:02:00+08:00", "b"))
+	if a.Health(1001).IsStale {
+		t.Fatal("expected camera not to be stale after 1 unchanged poll")
+	}
+	a.Record(poll("2016-12-01T10:03:00+08:00", "2016-12-01T10:03:00+08:00", "b"))
+	health = a.Health(1001)
+	if !health.IsStale || health.LastChangedAt != "2016-12-01T10:01:00+08:00" {
+		t.Fatalf("expected camera to be stale after 2 unchanged polls: %+v", health)
+	}
+
+	// a lagging timestamp is stale even if the image changes
+	a.Record(poll("2016-12-01T11:00:00+08:00", "2016-12-01T10:30:00+08:00", "c"))
+	if !a.Health(1001).IsStale {
+		t.Fatal("expected lagging camera to be stale")
+	}
+	a.Record(poll("2016-12-01T11:01:00+08:00", "2016-12-01T11:01:00+08:00", "d"))
+	if a.Health(1001).IsStale {
+		t.Fatal("expected camera to recover")
+	}
+}
+
+// slowImages serves camera images slowly, recording the most downloads in flight at once
+type slowImages struct {
+	inFlight, maxInFlight int32
+}
+
+func (s *slowImages) RoundTrip(r *http.Request) (*http.Response, error) {
+	n := atomic.AddInt32(&s.inFlight, 1)
+	defer atomic.AddInt32(&s.inFlight, -1)
+	for {
+		max := atomic.LoadInt32(&s.maxInFlight)
+		if n <= max || atomic.CompareAndSwapInt32(&s.maxInFlight, max, n) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader("image")),
+		Header:     http.Header{},
+	}, nil
+}
+
+func TestArchive_RecordStoresImages(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	images := &slowImages{}
+	a := archive.New(dir)
+	a.Workers = 3
+	a.HTTPClient = &http.Client{Transport: images}
+
+	item := datagovsg.TrafficImagesResultItem{Timestamp: "2016-12-01T10:00:00+08:00"}
+	for id := 1001; id <= 1010; id++ {
+		camera := datagovsg.TrafficImageCamera{
+			Timestamp: "2016-12-01T10:00:00+08:00",
+			CameraID:  id,
+			Image:     fmt.Sprintf("https://images.data.gov.sg/%v.jpg", id),
+		}
+		camera.ImageMetadata.MD5 = "78805a221a988e79ef3f42d7c5bfd418" // md5 of "image"
+		item.Cameras = append(item.Cameras, camera)
+	}
+	a.Record(&datagovsg.TrafficImagesResult{Items: []datagovsg.TrafficImagesResultItem{item}})
+
+	for id := 1001; id <= 1010; id++ {
+		data, err := ioutil.ReadFile(filepath.Join(dir, fmt.Sprint(id), "20161201T100000.jpg"))
+		if err != nil || string(data) != "image" {
+			t.Errorf("expected the image of camera %v to be stored, got %q, %v", id, data, err)
+		}
+	}
+	if max := atomic.LoadInt32(&images.maxInFlight); max < 2 || max > 3 {
+		t.Errorf("expected up to 3 concurrent downloads, got %v", max)
+	}
+}
+
+// failingImages fails every download until fail is cleared, counting the downloads attempted
+type failingImages struct {
+	fail      int32
+	downloads int32
+}
+
+func (f *failingImages) RoundTrip(r *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&f.downloads, 1)
+	if atomic.LoadInt32(&f.fail) != 0 {
+		return nil, fmt.Errorf("connection reset")
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader("image")),
+		Header:     http.Header{},
+	}, nil
+}
+
+func TestArchive_RecordRetriesFailedImages(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	images := &failingImages{fail: 1}
+	a := archive.New(dir)
+	a.HTTPClient = &http.Client{Transport: images}
+	path := filepath.Join(dir, "1001", "20161201T100000.jpg")
+	resp := poll("2016-12-01T10:00:00+08:00", "2016-12-01T10:00:00+08:00", "78805a221a988e79ef3f42d7c5bfd418") // md5 of "image"
+
+	a.Record(resp)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected no image after a failed download, got %v", err)
+	}
+
+	// the same image is downloaded again on the next poll, and only until it is stored
+	atomic.StoreInt32(&images.fail, 0)
+	a.Record(resp)
+	if data, err := ioutil.ReadFile(path); err != nil || string(data) != "image" {
+		t.Fatalf("expected the image to be stored on the next poll, got %q, %v", data, err)
+	}
+	a.Record(resp)
+	if downloads := atomic.LoadInt32(&images.downloads); downloads != 2 {
+		t.Errorf("expected 2 downloads, got %v", downloads)
+	}
+	if health := a.Health(1001); health.UnchangedPolls != 2 {
+		t.Errorf("expected the retries to count as unchanged polls, got %+v", health)
+	}
+}
@@ -0,0 +1,249 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// Config is the server configuration. Each setting is read from, in order of precedence: a command-line flag,
+// an environment variable, the YAML config file, and its default.
+type Config struct {
+	IP     string `yaml:"ip"`
+	Port   string `yaml:"port"`
+	APIKey string `yaml:"api_key"`
+
+	// Boundaries is the path of a GeoJSON file of planning area boundaries, such as the URA Master Plan
+	// planning area boundaries from data.gov.sg, to use instead of the embedded simplified boundaries.
+	Boundaries string `yaml:"boundaries"`
+
+	// ArchiveDir is where traffic camera images are archived. Images are not archived if it is empty.
+	ArchiveDir        string `yaml:"archive_dir"`
+	ArchiveStalePolls int    `yaml:"archive_stale_polls"`
+
+	// CameraCatalogue is the path of a JSON catalogue of traffic camera locations and directions.
+	// The embedded catalogue is used if it is empty.
+	CameraCatalogue string `yaml:"camera_catalogue"`
+
+	// Query cost limits. A limit of 0 disables it.
+	FetchCost int `yaml:"fetch_cost"`
+	MaxDepth  int `yaml:"max_depth"`
+	MaxCost   int `yaml:"max_cost"`
+
+	// PersistedQueries is the number of automatic persisted queries kept in memory
+	PersistedQueries int `yaml:"persisted_queries"`
+
+	// Allowlist is the path of a JSON file of allowed queries. If set, only those queries are executed.
+	Allowlist string `yaml:"allowlist"`
+
+	MaxBatch    int `yaml:"max_batch"`
+	ResultCache int `yaml:"result_cache"`
+
+	// ShutdownDelay is how many seconds the server keeps serving, reporting that it is stopping on /readyz,
+	// before it stops accepting requests, so that load balancers take it out of rotation first
+	ShutdownDelay int `yaml:"shutdown_delay"`
+
+	// ShutdownTimeout is how many seconds in-flight requests are given to finish when the server is stopped
+	ShutdownTimeout int `yaml:"shutdown_timeout"`
+
+	// OTLPEndpoint is the host:port of an OpenTelemetry collector to export traces to over OTLP/HTTP.
+	// Nothing is traced if it is empty.
+	OTLPEndpoint string `yaml:"otlp_endpoint"`
+
+	// File is the config file the configuration was read from, if any
+	File string `yaml:"-"`
+
+	// PrintConfig is set by --print-config: print the configuration and exit instead of starting the server
+	PrintConfig bool `yaml:"-"`
+}
+
+// Default returns the default configuration
+func Default() *Config {
+	return &Config{
+		Port:              "3000",
+		ArchiveStalePolls: 5,
+		FetchCost:         20,
+		MaxDepth:          15,
+		MaxCost:           1000,
+		PersistedQueries:  1000,
+		MaxBatch:          10,
+		ResultCache:       1000,
+		ShutdownDelay:     5,
+		ShutdownTimeout:   30,
+	}
+}
+
+type setting struct {
+	name  string
+	env   []string
+	usage string
+	value func(c *Config) interface{}
+}
+
+// settings lists every setting with its flag name (which is also its config file key, with "-" as "_") and
+// environment variables, the first one set winning
+var settings = []setting{
+	{"ip", []string{"OPENSHIFT_GO_IP", "DATAGOVSG_IP"}, "IP address to listen on (all addresses if empty)",
+		func(c *Config) interface{} { return &c.IP }},
+	{"port", []string{"OPENSHIFT_GO_PORT", "DATAGOVSG_PORT"}, "port to listen on",
+		func(c *Config) interface{} { return &c.Port }},
+	{"api-key", []string{"DATAGOVSG_API_KEY"}, "data.gov.sg API key (required)",
+		func(c *Config) interface{} { return &c.APIKey }},
+	{"boundaries", []string{"DATAGOVSG_BOUNDARIES"}, "GeoJSON file of planning area boundaries, replacing the embedded ones",
+		func(c *Config) interface{} { return &c.Boundaries }},
+	{"archive-dir", []string{"DATAGOVSG_ARCHIVE_DIR"}, "directory to archive traffic camera images to",
+		func(c *Config) interface{} { return &c.ArchiveDir }},
+	{"archive-stale-polls", []string{"DATAGOVSG_ARCHIVE_STALE_POLLS"}, "polls without a new image before a camera is stale",
+		func(c *Config) interface{} { return &c.ArchiveStalePolls }},
+	{"camera-catalogue", []string{"DATAGOVSG_CAMERA_CATALOGUE"}, "JSON catalogue of traffic camera locations and directions",
+		func(c *Config) interface{} { return &c.CameraCatalogue }},
+	{"fetch-cost", []string{"DATAGOVSG_FETCH_COST"}, "query cost of each distinct upstream fetch",
+		func(c *Config) interface{} { return &c.FetchCost }},
+	{"max-depth", []string{"DATAGOVSG_MAX_DEPTH"}, "maximum query depth (0 for no limit)",
+		func(c *Config) interface{} { return &c.MaxDepth }},
+	{"max-cost", []string{"DATAGOVSG_MAX_COST"}, "maximum cost of a query, or of all operations of a batch (0 for no limit)",
+		func(c *Config) interface{} { return &c.MaxCost }},
+	{"persisted-queries", []string{"DATAGOVSG_PERSISTED_QUERIES"}, "number of automatic persisted queries kept in memory",
+		func(c *Config) interface{} { return &c.PersistedQueries }},
+	{"allowlist", []string{"DATAGOVSG_ALLOWLIST"}, "JSON file of the only queries allowed to run",
+		func(c *Config) interface{} { return &c.Allowlist }},
+	{"max-batch", []string{"DATAGOVSG_MAX_BATCH"}, "maximum number of operations in a batched request",
+		func(c *Config) interface{} { return &c.MaxBatch }},
+	{"result-cache", []string{"DATAGOVSG_RESULT_CACHE"}, "number of query results kept in memory (0 to disable)",
+		func(c *Config) interface{} { return &c.ResultCache }},
+	{"shutdown-delay", []string{"DATAGOVSG_SHUTDOWN_DELAY"}, "seconds to keep serving, reported as stopping, before shutting down",
+		func(c *Config) interface{} { return &c.ShutdownDelay }},
+	{"shutdown-timeout", []string{"DATAGOVSG_SHUTDOWN_TIMEOUT"}, "seconds to wait for in-flight requests when stopping",
+		func(c *Config) interface{} { return &c.ShutdownTimeout }},
+	{"otlp-endpoint", []string{"DATAGOVSG_OTLP_ENDPOINT"}, "host:port of an OpenTelemetry collector to send traces to over OTLP/HTTP",
+		func(c *Config) interface{} { return &c.OTLPEndpoint }},
+}
+
+// Load reads the configuration from command-line arguments (without the program name), environment variables
+// looked up with getenv, and the config file given by --config or DATAGOVSG_CONFIG, and validates it
+func Load(args []string, getenv func(string) string) (*Config, error) {
+	c := Default()
+
+	// flags are parsed into their own Config, and only copied over the others if they were set
+	fromFlags := Default()
+	fs := flag.NewFlagSet("data-gov-sg-graphql-go", flag.ContinueOnError)
+	fs.StringVar(&fromFlags.File, "config", "", "YAML config file (or DATAGOVSG_CONFIG)")
+	fs.BoolVar(&c.PrintConfig, "print-config", false, "print the configuration and exit")
+	for _, s := range settings {
+		switch v := s.value(fromFlags).(type) {
+		case *string:
+			fs.StringVar(v, s.name, *v, s.usage)
+		case *int:
+			fs.IntVar(v, s.name, *v, s.usage)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	// config file
+	c.File = getenv("DATAGOVSG_CONFIG")
+	if set["config"] {
+		c.File = fromFlags.File
+	}
+	if c.File != "" {
+		data, err := ioutil.ReadFile(c.File)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.UnmarshalStrict(data, c); err != nil {
+			return nil, fmt.Errorf("%v: %v", c.File, err)
+		}
+	}
+
+	// environment variables, then flags
+	for _, s := range settings {
+		for _, env := range s.env {
+			if value := getenv(env); value != "" {
+				if err := s.set(c, value); err != nil {
+					return nil, fmt.Errorf("%v: %v", env, err)
+				}
+				break
+			}
+		}
+		if set[s.name] {
+			s.copy(c, fromFlags)
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s setting) set(c *Config, value string) error {
+	switch v := s.value(c).(type) {
+	case *string:
+		*v = value
+	case *int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		*v = n
+	}
+	return nil
+}
+
+func (s setting) copy(dst *Config, src *Config) {
+	switch v := s.value(dst).(type) {
+	case *string:
+		*v = *s.value(src).(*string)
+	case *int:
+		*v = *s.value(src).(*int)
+	}
+}
+
+// Validate checks that the configuration is complete and sensible
+func (c *Config) Validate() error {
+	if c.APIKey == "" {
+		return fmt.Errorf("a data.gov.sg API key is required: set --api-key, DATAGOVSG_API_KEY or api_key in the config file " +
+			"(get one at https://developers.data.gov.sg)")
+	}
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("port %q must be a number from 1 to 65535", c.Port)
+	}
+	for _, s := range settings {
+		if v, ok := s.value(c).(*int); ok && *v < 0 {
+			return fmt.Errorf("%v must not be negative, got %v", s.name, *v)
+		}
+	}
+	if c.ArchiveStalePolls == 0 {
+		return fmt.Errorf("archive-stale-polls must be at least 1")
+	}
+	if c.MaxBatch == 0 {
+		return fmt.Errorf("max-batch must be at least 1")
+	}
+	return nil
+}
+
+// Write writes the configuration as YAML, with the API key masked
+func (c *Config) Write(w io.Writer) error {
+	masked := *c
+	if n := len(masked.APIKey); n > 4 {
+		masked.APIKey = strings.Repeat("*", n-4) + masked.APIKey[n-4:]
+	} else if n > 0 {
+		masked.APIKey = "****"
+	}
+	data, err := yaml.Marshal(masked)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,93 @@
+package config_test
+
+import (
+	"github.com/sogko/data-gov-sg-graphql-go/lib/config"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func getenv(env map[string]string) func(string) string {
+	return func(name string) string {
+		return env[name]
+	}
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "config.yaml")
+	ioutil.WriteFile(file, []byte("api_key: from-file\nboundaries: boundaries.json\nport: 8000\nmax_cost: 500\nmax_depth: 10\n"), 0644)
+
+	c, err := config.Load(
+		[]string{"--config", file, "--max-depth", "12"},
+		getenv(map[string]string{"DATAGOVSG_PORT": "9000", "DATAGOVSG_MAX_DEPTH": "11"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.APIKey != "from-file" || c.Boundaries != "boundaries.json" || c.MaxCost != 500 {
+		t.Errorf("expected settings from the config file, got %+v", c)
+	}
+	if c.Port != "9000" {
+		t.Errorf("expected environment to override config file, got port %v", c.Port)
+	}
+	if c.MaxDepth != 12 {
+		t.Errorf("expected flag to override environment, got max depth %v", c.MaxDepth)
+	}
+	if c.MaxBatch != config.Default().MaxBatch {
+		t.Errorf("expected default max batch, got %v", c.MaxBatch)
+	}
+}
+
+func TestLoad_UnknownKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "config.yaml")
+	ioutil.WriteFile(file, []byte("api_key: from-file\nboundaries: boundaries.json\nmax_costs: 500\n"), 0644)
+
+	if _, err := config.Load([]string{"--config", file}, getenv(nil)); err == nil || !strings.Contains(err.Error(), "max_costs") {
+		t.Fatalf("expected an error for a misspelt key, got %v", err)
+	}
+}
+
+func TestLoad_IP(t *testing.T) {
+	c, err := config.Load(nil, getenv(map[string]string{
+		"DATAGOVSG_API_KEY":    "key",
+		"DATAGOVSG_BOUNDARIES": "boundaries.json",
+		"DATAGOVSG_IP":         "127.0.0.1",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.IP != "127.0.0.1" || c.Port != "3000" {
+		t.Errorf("expected 127.0.0.1:3000, got %v:%v", c.IP, c.Port)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	for _, test := range []struct {
+		args     []string
+		env      map[string]string
+		expected string
+	}{
+		{nil, nil, "API key is required"},
+		{nil, map[string]string{"DATAGOVSG_API_KEY": "key", "DATAGOVSG_MAX_COST": "lots"}, "DATAGOVSG_MAX_COST"},
+		{[]string{"--api-key", "key", "--boundaries", "b.json", "--port", "99999"}, nil, "port"},
+		{[]string{"--api-key", "key", "--boundaries", "b.json", "--max-batch", "-1"}, nil, "max-batch"},
+		{[]string{"--api-key", "key", "--config", "/does/not/exist.yaml"}, nil, "exist.yaml"},
+	} {
+		_, err := config.Load(test.args, getenv(test.env))
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("Load(%v, %v): expected error mentioning %q, got %v", test.args, test.env, test.expected, err)
+		}
+	}
+}
@@ -2,9 +2,12 @@ package datagovsg
 
 import (
 	"encoding/json"
+	"fmt"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/net/context"
 	"net/http"
 	"sync"
+	"time"
 )
 
 // ClientResult contains the result from the HTTP request from Client
@@ -13,20 +16,70 @@ type ClientResult struct {
 	Err  error
 }
 
-// Client is a special HTTP client that batches HTTP requests for the same URL, returning requests through channels
+// Observer is told about the upstream requests made by Clients, e.g. to export metrics
+type Observer interface {
+	// Fetched is called when an upstream request completes. status is 0 if no response was received.
+	Fetched(url string, status int, duration time.Duration, err error)
+
+	// Waited is called when a request is served by another request for the same URL instead of a fetch of its own:
+	// one still in flight, or one that has completed if memoized is true
+	Waited(url string, memoized bool)
+}
+
+// DefaultObserver is the Observer of new Clients
+var DefaultObserver Observer
+
+// Client is a special HTTP client that batches HTTP requests for the same URL, returning requests through channels.
+// Completed results are kept for the lifetime of the Client, so a Client is meant to be scoped to a single
+// GraphQL request: fields that need the same dataset share one upstream fetch regardless of when they resolve.
 type Client struct {
 	APIKey string
 
+	// Observer, if not nil, is told about every request made through the Client
+	Observer Observer
+
+	// HTTPClient makes the upstream requests. If nil, a client without a timeout is used.
+	HTTPClient *http.Client
+
+	// listeners, results and spans are shared with the Client's operations
 	listeners    map[string][]chan ClientResult
-	listenerLock sync.RWMutex
+	results      map[string]ClientResult
+	listenerLock *sync.RWMutex
+
+	// dataAt records the time of the latest data of each URL requested through this Client, for Touched and Freshness
+	dataAt    map[string]time.Time
+	failed    bool
+	trackLock sync.RWMutex
+
+	// spans holds the span of the fetch of each URL, for waiters to link to
+	spans map[string]trace.SpanContext
 }
 
 // NewClient returns a new Client
 func NewClient(apiKey string) *Client {
 	return &Client{
 		APIKey:       apiKey,
+		Observer:     DefaultObserver,
 		listeners:    map[string][]chan ClientResult{},
-		listenerLock: sync.RWMutex{},
+		results:      map[string]ClientResult{},
+		listenerLock: &sync.RWMutex{},
+		dataAt:       map[string]time.Time{},
+		spans:        map[string]trace.SpanContext{},
+	}
+}
+
+// Operation returns a Client that shares c's requests and results, so identical upstream requests are still only made
+// once, but tracks its own Touched and Freshness. It is meant for each operation of a batched GraphQL request.
+func (c *Client) Operation() *Client {
+	return &Client{
+		APIKey:       c.APIKey,
+		Observer:     c.Observer,
+		HTTPClient:   c.HTTPClient,
+		listeners:    c.listeners,
+		results:      c.results,
+		listenerLock: c.listenerLock,
+		dataAt:       map[string]time.Time{},
+		spans:        c.spans,
 	}
 }
 
@@ -43,6 +96,9 @@ func (c *Client) broadcastOnce(url string, result ClientResult) {
 	listeners, _ := c.listeners[url]
 	c.listeners[url] = nil
 	delete(c.listeners, url)
+	if result.Err == nil {
+		c.results[url] = result
+	}
 	c.listenerLock.Unlock()
 
 	for _, listener := range listeners {
@@ -51,23 +107,65 @@ func (c *Client) broadcastOnce(url string, result ClientResult) {
 	}
 }
 
-func (c *Client) register(url string) (ch chan ClientResult, alreadyExists bool) {
-	ch = make(chan ClientResult)
+// register adds a listener for the given URL. If a result for the URL has already been fetched, it is sent
+// through the returned channel straight away. The returned span is that of the new fetch if alreadyExists is false,
+// or of the wait for another fetch if it is true.
+func (c *Client) register(ctx context.Context, method string, url string) (ch chan ClientResult, alreadyExists bool, span trace.Span) {
 	c.listenerLock.Lock()
+	if result, ok := c.results[url]; ok {
+		span = startWaitSpan(ctx, url, c.spans[url], true)
+		c.listenerLock.Unlock()
+		ch = make(chan ClientResult, 1)
+		ch <- result
+		close(ch)
+		if c.Observer != nil {
+			c.Observer.Waited(url, true)
+		}
+		return ch, true, span
+	}
+	ch = make(chan ClientResult)
 	_, alreadyExists = c.listeners[url]
-	if !alreadyExists {
+	if alreadyExists {
+		span = startWaitSpan(ctx, url, c.spans[url], false)
+	} else {
 		c.listeners[url] = []chan ClientResult{}
+		span = startFetchSpan(ctx, method, url)
+		c.spans[url] = span.SpanContext()
 	}
 	c.listeners[url] = append(c.listeners[url], ch)
 	c.listenerLock.Unlock()
-	return ch, alreadyExists
+	if alreadyExists && c.Observer != nil {
+		c.Observer.Waited(url, false)
+	}
+	return ch, alreadyExists, span
+}
+
+// track records a result for Touched and Freshness before passing it on
+func (c *Client) track(url string, ch chan ClientResult) chan ClientResult {
+	out := make(chan ClientResult, 1)
+	go func() {
+		result := <-ch
+		c.trackLock.Lock()
+		if result.Err == nil {
+			c.dataAt[url] = dataTimestamp(result.Body, time.Now())
+			if status := apiStatus(result.Body); status != "" && status != "healthy" {
+				c.failed = true
+			}
+		} else {
+			c.failed = true
+		}
+		c.trackLock.Unlock()
+		out <- result
+		close(out)
+	}()
+	return out
 }
 
-func (c *Client) request(method string, url string, target interface{}) chan ClientResult {
+func (c *Client) request(ctx context.Context, method string, url string, target interface{}) chan ClientResult {
 
-	ch, alreadyExists := c.register(url)
+	ch, alreadyExists, span := c.register(ctx, method, url)
 	if alreadyExists {
-		return ch
+		return c.track(url, endSpanOnResult(span, ch))
 	}
 
 	// set up go-routine to make batched request
@@ -76,6 +174,7 @@ func (c *Client) request(method string, url string, target interface{}) chan Cli
 		// create request
 		req, err := http.NewRequest(method, url, nil)
 		if err != nil {
+			endSpan(span, 0, err)
 			c.broadcastOnce(url, ClientResult{
 				Err: err,
 			})
@@ -86,9 +185,15 @@ func (c *Client) request(method string, url string, target interface{}) chan Cli
 		req.Header.Set("api-key", c.APIKey)
 
 		// make HTTP request
-		client := &http.Client{}
+		start := time.Now()
+		client := c.HTTPClient
+		if client == nil {
+			client = &http.Client{}
+		}
 		res, err := client.Do(req)
 		if err != nil {
+			c.observe(url, 0, start, err)
+			endSpan(span, 0, err)
 			c.broadcastOnce(url, ClientResult{
 				Err: err,
 			})
@@ -96,8 +201,20 @@ func (c *Client) request(method string, url string, target interface{}) chan Cli
 		}
 		defer res.Body.Close()
 
+		if res.StatusCode >= 400 {
+			err = fmt.Errorf("%v: %v", url, res.Status)
+			c.observe(url, res.StatusCode, start, err)
+			endSpan(span, res.StatusCode, err)
+			c.broadcastOnce(url, ClientResult{
+				Err: err,
+			})
+			return
+		}
+
 		// decode as JSON response
 		err = json.NewDecoder(res.Body).Decode(target)
+		c.observe(url, res.StatusCode, start, err)
+		endSpan(span, res.StatusCode, err)
 
 		c.broadcastOnce(url, ClientResult{
 			Body: target,
@@ -105,10 +222,22 @@ func (c *Client) request(method string, url string, target interface{}) chan Cli
 		})
 
 	}(url)
-	return ch
+	return c.track(url, ch)
+}
+
+func (c *Client) observe(url string, status int, start time.Time, err error) {
+	if c.Observer != nil {
+		c.Observer.Fetched(url, status, time.Since(start), err)
+	}
 }
 
 // Get allows user to make a /GET HTTP request, getting it through a channel.
 func (c *Client) Get(url string, target interface{}) chan ClientResult {
-	return c.request("GET", url, target)
+	return c.GetContext(context.Background(), url, target)
+}
+
+// GetContext is Get, tracing the request as a child of the span in ctx. If the request is served by another request
+// for the same URL, its span is linked to the span of that request's fetch.
+func (c *Client) GetContext(ctx context.Context, url string, target interface{}) chan ClientResult {
+	return c.request(ctx, "GET", url, target)
 }
@@ -0,0 +1,75 @@
+package datagovsg
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/context"
+	"net/url"
+)
+
+var tracer = otel.Tracer("github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg")
+
+// endpoint returns the path of a data.gov.sg URL, to name spans by
+func endpoint(rawurl string) string {
+	if u, err := url.Parse(rawurl); err == nil {
+		return u.Path
+	}
+	return rawurl
+}
+
+// startFetchSpan starts the span of an upstream request
+func startFetchSpan(ctx context.Context, method string, url string) trace.Span {
+	_, span := tracer.Start(ctx, method+" "+endpoint(url),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", method),
+			attribute.String("url.full", url),
+		),
+	)
+	return span
+}
+
+// startWaitSpan starts the span of a request served by another request's fetch, linked to the fetch's span
+func startWaitSpan(ctx context.Context, url string, fetch trace.SpanContext, memoized bool) trace.Span {
+	_, span := tracer.Start(ctx, "wait "+endpoint(url),
+		trace.WithLinks(trace.Link{SpanContext: fetch}),
+		trace.WithAttributes(
+			attribute.String("url.full", url),
+			attribute.Bool("datagovsg.memoized", memoized),
+		),
+	)
+	return span
+}
+
+// endSpan ends a span with the outcome of a request. status is 0 if no response was received.
+func endSpan(span trace.Span, status int, err error) {
+	if status != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+	}
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case status >= 400:
+		span.SetStatus(codes.Error, "")
+	}
+	span.End()
+}
+
+// endSpanOnResult ends a waiter's span once its result arrives
+func endSpanOnResult(span trace.Span, ch chan ClientResult) chan ClientResult {
+	if !span.IsRecording() {
+		span.End()
+		return ch
+	}
+	out := make(chan ClientResult, 1)
+	go func() {
+		result := <-ch
+		endSpan(span, 0, result.Err)
+		out <- result
+		close(out)
+	}()
+	return out
+}
@@ -1,5 +1,25 @@
 package datagovsg
 
+import (
+	"math"
+	"time"
+)
+
+// earthRadius is the mean radius of the Earth in metres
+const earthRadius = 6371008.8
+
+// SGT is the timezone that data.gov.sg timestamps are reported in
+var SGT = time.FixedZone("SGT", 8*60*60)
+
+// ParseTimestamp parses a data.gov.sg timestamp (RFC3339), or a date_time argument (YYYY-MM-DDTHH:mm:ss)
+// which is assumed to be in Singapore time.
+func ParseTimestamp(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02T15:04:05", s, SGT)
+}
+
 type APIInfo struct {
 	Status string `json:"status,omitempty"`
 }
@@ -8,16 +28,100 @@ type Location struct {
 	Latitude  float64 `json:"latitude,omitempty"`
 }
 
+// DistanceTo returns the great-circle distance in metres between two locations
+func (l Location) DistanceTo(o Location) float64 {
+	lat1 := l.Latitude * math.Pi / 180
+	lat2 := o.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (o.Longitude - l.Longitude) * math.Pi / 180
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// BearingTo returns the initial bearing in degrees (0-360, clockwise from north) from this location to another
+func (l Location) BearingTo(o Location) float64 {
+	lat1 := l.Latitude * math.Pi / 180
+	lat2 := o.Latitude * math.Pi / 180
+	dLng := (o.Longitude - l.Longitude) * math.Pi / 180
+	y := math.Sin(dLng) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLng)
+	return math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+}
+
+// DistanceToSegment returns the distance in metres from this location to the nearest point on the line segment a-b.
+// It uses a local equirectangular projection, which is accurate enough over city-scale distances.
+func (l Location) DistanceToSegment(a Location, b Location) float64 {
+	scale := math.Cos(l.Latitude * math.Pi / 180)
+	ax, ay := (a.Longitude-l.Longitude)*scale, a.Latitude-l.Latitude
+	bx, by := (b.Longitude-l.Longitude)*scale, b.Latitude-l.Latitude
+	dx, dy := bx-ax, by-ay
+
+	t := 0.0
+	if lengthSq := dx*dx + dy*dy; lengthSq > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/lengthSq))
+	}
+	nearest := Location{
+		Longitude: l.Longitude + (ax+t*dx)/scale,
+		Latitude:  l.Latitude + ay + t*dy,
+	}
+	return l.DistanceTo(nearest)
+}
+
+// DistanceToLine returns the distance in metres from this location to the nearest point on a line string
+func (l Location) DistanceToLine(line []Location) float64 {
+	switch len(line) {
+	case 0:
+		return math.Inf(1)
+	case 1:
+		return l.DistanceTo(line[0])
+	}
+	min := math.Inf(1)
+	for i := 1; i < len(line); i++ {
+		min = math.Min(min, l.DistanceToSegment(line[i-1], line[i]))
+	}
+	return min
+}
+
 type DatetimeRange struct {
 	Start string `json:"start,omitempty"`
 	End   string `json:"end,omitempty"`
 }
 
+// Contains returns true if t falls within [Start, End)
+func (r DatetimeRange) Contains(t time.Time) bool {
+	start, err := ParseTimestamp(r.Start)
+	if err != nil {
+		return false
+	}
+	end, err := ParseTimestamp(r.End)
+	if err != nil {
+		return false
+	}
+	return !t.Before(start) && t.Before(end)
+}
+
 type Area struct {
 	Name          string   `json:"name,omitempty"`
 	LabelLocation Location `json:"label_location,omitempty"`
 }
 
+// NearestArea returns the area whose label location is closest to the given location.
+// Areas without a label location (e.g. "national") are skipped.
+func NearestArea(areas []Area, loc Location) Area {
+	nearest := Area{}
+	min := math.Inf(1)
+	for _, area := range areas {
+		if area.LabelLocation.Latitude == 0 && area.LabelLocation.Longitude == 0 {
+			continue
+		}
+		if d := loc.DistanceTo(area.LabelLocation); d < min {
+			min = d
+			nearest = area
+		}
+	}
+	return nearest
+}
+
 type Speed struct {
 	High int `json:"high,omitempty"`
 	Low  int `json:"low,omitempty"`
@@ -45,3 +149,20 @@ type RegionWeatherForecast struct {
 	Central string `json:"central,omitempty"`
 	West    string `json:"west,omitempty"`
 }
+
+// ByRegion returns the forecast for the given region name (south, north, east, central or west)
+func (r RegionWeatherForecast) ByRegion(name string) string {
+	switch name {
+	case "south":
+		return r.South
+	case "north":
+		return r.North
+	case "east":
+		return r.East
+	case "central":
+		return r.Central
+	case "west":
+		return r.West
+	}
+	return ""
+}
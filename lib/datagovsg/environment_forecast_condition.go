@@ -0,0 +1,152 @@
+package datagovsg
+
+import (
+	"log"
+	"strings"
+	"sync"
+)
+
+// ForecastCondition is a normalised weather forecast condition
+type ForecastCondition string
+
+const (
+	ConditionFair                               ForecastCondition = "FAIR"
+	ConditionFairAndWarm                        ForecastCondition = "FAIR_AND_WARM"
+	ConditionSunny                              ForecastCondition = "SUNNY"
+	ConditionPartlyCloudy                       ForecastCondition = "PARTLY_CLOUDY"
+	ConditionCloudy                             ForecastCondition = "CLOUDY"
+	ConditionOvercast                           ForecastCondition = "OVERCAST"
+	ConditionSlightlyHazy                       ForecastCondition = "SLIGHTLY_HAZY"
+	ConditionHazy                               ForecastCondition = "HAZY"
+	ConditionMist                               ForecastCondition = "MIST"
+	ConditionFog                                ForecastCondition = "FOG"
+	ConditionDrizzle                            ForecastCondition = "DRIZZLE"
+	ConditionLightRain                          ForecastCondition = "LIGHT_RAIN"
+	ConditionModerateRain                       ForecastCondition = "MODERATE_RAIN"
+	ConditionHeavyRain                          ForecastCondition = "HEAVY_RAIN"
+	ConditionPassingShowers                     ForecastCondition = "PASSING_SHOWERS"
+	ConditionLightShowers                       ForecastCondition = "LIGHT_SHOWERS"
+	ConditionShowers                            ForecastCondition = "SHOWERS"
+	ConditionHeavyShowers                       ForecastCondition = "HEAVY_SHOWERS"
+	ConditionThunderyShowers                    ForecastCondition = "THUNDERY_SHOWERS"
+	ConditionHeavyThunderyShowers               ForecastCondition = "HEAVY_THUNDERY_SHOWERS"
+	ConditionHeavyThunderyShowersWithGustyWinds ForecastCondition = "HEAVY_THUNDERY_SHOWERS_WITH_GUSTY_WINDS"
+	ConditionWindy                              ForecastCondition = "WINDY"
+	ConditionWindyCloudy                        ForecastCondition = "WINDY_CLOUDY"
+	ConditionWindyFair                          ForecastCondition = "WINDY_FAIR"
+	ConditionWindyRain                          ForecastCondition = "WINDY_RAIN"
+	ConditionWindyShowers                       ForecastCondition = "WINDY_SHOWERS"
+	ConditionStrongWinds                        ForecastCondition = "STRONG_WINDS"
+	ConditionStrongWindsRain                    ForecastCondition = "STRONG_WINDS_RAIN"
+	ConditionStrongWindsShowers                 ForecastCondition = "STRONG_WINDS_SHOWERS"
+	ConditionSnow                               ForecastCondition = "SNOW"
+	ConditionSandstorm                          ForecastCondition = "SANDSTORM"
+	ConditionUnknown                            ForecastCondition = "UNKNOWN"
+)
+
+// Day and night variants of a forecast condition
+const (
+	VariantDay   = "DAY"
+	VariantNight = "NIGHT"
+)
+
+// ForecastConditionInfo is the normalised form of a forecast string
+type ForecastConditionInfo struct {
+	Condition ForecastCondition `json:"condition"`
+
+	// Variant is VariantDay or VariantNight for conditions that NEA forecasts differently by day and night, else ""
+	Variant string `json:"variant"`
+
+	// Icon is NEA's abbreviation for the forecast, e.g. "PC" for Partly Cloudy (Day), or "" if unknown
+	Icon string `json:"icon"`
+}
+
+// forecastConditions maps NEA's forecast strings (lower case) to their normalised conditions
+var forecastConditions = map[string]ForecastConditionInfo{
+	"mist":          {ConditionMist, "", "BR"},
+	"cloudy":        {ConditionCloudy, "", "CL"},
+	"drizzle":       {ConditionDrizzle, "", "DR"},
+	"fair (day)":    {ConditionFair, VariantDay, "FA"},
+	"fog":           {ConditionFog, "", "FG"},
+	"fair (night)":  {ConditionFair, VariantNight, "FN"},
+	"fair & warm":   {ConditionFairAndWarm, "", "FW"},
+	"fair and warm": {ConditionFairAndWarm, "", "FW"},
+	"heavy thundery showers with gusty winds": {ConditionHeavyThunderyShowersWithGustyWinds, "", "HG"},
+	"heavy rain":             {ConditionHeavyRain, "", "HR"},
+	"heavy showers":          {ConditionHeavyShowers, "", "HS"},
+	"heavy thundery showers": {ConditionHeavyThunderyShowers, "", "HT"},
+	"hazy":                   {ConditionHazy, "", "HZ"},
+	"slightly hazy":          {ConditionSlightlyHazy, "", "LH"},
+	"light rain":             {ConditionLightRain, "", "LR"},
+	"light showers":          {ConditionLightShowers, "", "LS"},
+	"overcast":               {ConditionOvercast, "", "OC"},
+	"partly cloudy (day)":    {ConditionPartlyCloudy, VariantDay, "PC"},
+	"partly cloudy (night)":  {ConditionPartlyCloudy, VariantNight, "PN"},
+	"passing showers":        {ConditionPassingShowers, "", "PS"},
+	"moderate rain":          {ConditionModerateRain, "", "RA"},
+	"showers":                {ConditionShowers, "", "SH"},
+	"strong winds, showers":  {ConditionStrongWindsShowers, "", "SK"},
+	"snow":                   {ConditionSnow, "", "SN"},
+	"strong winds, rain":     {ConditionStrongWindsRain, "", "SR"},
+	"sandstorm":              {ConditionSandstorm, "", "SS"},
+	"sunny":                  {ConditionSunny, "", "SU"},
+	"strong winds":           {ConditionStrongWinds, "", "SW"},
+	"thundery showers":       {ConditionThunderyShowers, "", "TL"},
+	"windy, cloudy":          {ConditionWindyCloudy, "", "WC"},
+	"windy":                  {ConditionWindy, "", "WD"},
+	"windy, fair":            {ConditionWindyFair, "", "WF"},
+	"windy, rain":            {ConditionWindyRain, "", "WR"},
+	"windy, showers":         {ConditionWindyShowers, "", "WS"},
+	"fair":                   {ConditionFair, "", "FA"},
+	"partly cloudy":          {ConditionPartlyCloudy, "", "PC"},
+}
+
+// forecastKeywords matches the sentences used by the 4-day forecast (e.g. "Afternoon thundery showers."),
+// most specific first
+var forecastKeywords = []struct {
+	keyword string
+	info    ForecastConditionInfo
+}{
+	{"heavy thundery showers with gusty winds", forecastConditions["heavy thundery showers with gusty winds"]},
+	{"heavy thundery showers", forecastConditions["heavy thundery showers"]},
+	{"thundery showers", forecastConditions["thundery showers"]},
+	{"heavy showers", forecastConditions["heavy showers"]},
+	{"passing showers", forecastConditions["passing showers"]},
+	{"light showers", forecastConditions["light showers"]},
+	{"showers", forecastConditions["showers"]},
+	{"heavy rain", forecastConditions["heavy rain"]},
+	{"light rain", forecastConditions["light rain"]},
+	{"rain", forecastConditions["moderate rain"]},
+	{"slightly hazy", forecastConditions["slightly hazy"]},
+	{"hazy", forecastConditions["hazy"]},
+	{"partly cloudy", forecastConditions["partly cloudy"]},
+	{"cloudy", forecastConditions["cloudy"]},
+	{"fair and warm", forecastConditions["fair and warm"]},
+	{"fair", forecastConditions["fair"]},
+	{"windy", forecastConditions["windy"]},
+}
+
+var unknownForecastsLock sync.Mutex
+var unknownForecasts = map[string]bool{}
+
+// ParseForecastCondition normalises a forecast string. Strings that cannot be matched are logged (once each) and
+// returned as ConditionUnknown.
+func ParseForecastCondition(forecast string) ForecastConditionInfo {
+	s := strings.ToLower(strings.TrimSpace(forecast))
+	if info, ok := forecastConditions[s]; ok {
+		return info
+	}
+	for _, k := range forecastKeywords {
+		if strings.Contains(s, k.keyword) {
+			return k.info
+		}
+	}
+
+	unknownForecastsLock.Lock()
+	defer unknownForecastsLock.Unlock()
+	if !unknownForecasts[s] {
+		unknownForecasts[s] = true
+		log.Printf("Unknown forecast condition: %q", forecast)
+	}
+	return ForecastConditionInfo{Condition: ConditionUnknown}
+}
@@ -0,0 +1,27 @@
+package datagovsg_test
+
+import (
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"testing"
+)
+
+func TestParseForecastCondition(t *testing.T) {
+	tests := []struct {
+		forecast string
+		expected datagovsg.ForecastConditionInfo
+	}{
+		{"Partly Cloudy (Day)", datagovsg.ForecastConditionInfo{datagovsg.ConditionPartlyCloudy, datagovsg.VariantDay, "PC"}},
+		{"Partly Cloudy (Night)", datagovsg.ForecastConditionInfo{datagovsg.ConditionPartlyCloudy, datagovsg.VariantNight, "PN"}},
+		{"Thundery Showers", datagovsg.ForecastConditionInfo{datagovsg.ConditionThunderyShowers, "", "TL"}},
+		{"Heavy Thundery Showers with Gusty Winds", datagovsg.ForecastConditionInfo{datagovsg.ConditionHeavyThunderyShowersWithGustyWinds, "", "HG"}},
+		{"Afternoon thundery showers.", datagovsg.ForecastConditionInfo{datagovsg.ConditionThunderyShowers, "", "TL"}},
+		{"Partly cloudy.", datagovsg.ForecastConditionInfo{datagovsg.ConditionPartlyCloudy, "", "PC"}},
+		{"Volcanic ash", datagovsg.ForecastConditionInfo{datagovsg.ConditionUnknown, "", ""}},
+		{"", datagovsg.ForecastConditionInfo{datagovsg.ConditionUnknown, "", ""}},
+	}
+	for _, test := range tests {
+		if info := datagovsg.ParseForecastCondition(test.forecast); info != test.expected {
+			t.Errorf("ParseForecastCondition(%q): expected %+v, got %+v", test.forecast, test.expected, info)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package datagovsg
+
+import (
+	"time"
+)
+
+// Sources of a forecast timeline slot
+const (
+	SourceTwoHour        = "TWO_HOUR"
+	SourceTwentyFourHour = "TWENTY_FOUR_HOUR"
+	SourceFourDay        = "FOUR_DAY"
+)
+
+// ForecastSlot is one entry of a forecast timeline
+type ForecastSlot struct {
+	Source      string        `json:"source"`
+	ValidPeriod DatetimeRange `json:"valid_period"`
+	Forecast    string        `json:"forecast"`
+
+	// Temperature is the forecast temperature range, if the source has one. For twenty-four hour slots it is the
+	// range for the whole twenty-four hours.
+	Temperature *Temperature `json:"temperature"`
+}
+
+// ForecastTimelineSources holds the upstream results that a forecast timeline is merged from
+type ForecastTimelineSources struct {
+	TwoHourWeatherForecast        *TwoHourWeatherForecastResult
+	TwentyFourHourWeatherForecast *TwentyFourHourWeatherForecastResult
+	FourDayWeatherForecast        *FourDayWeatherForecastResult
+}
+
+// NewForecastTimeline merges the two-hour forecast for an area, the twenty-four hour forecast for a region and
+// the four-day forecast into chronologically ordered, non-overlapping slots. Where feeds overlap, the finer
+// grained one wins and later slots are trimmed to start where the previous one ended.
+// If area is empty, there is no two-hour slot. If region is "national" or empty, the general twenty-four hour
+// forecast is used.
+func NewForecastTimeline(area string, region string, src ForecastTimelineSources) []ForecastSlot {
+	candidates := []ForecastSlot{}
+
+	if resp := src.TwoHourWeatherForecast; resp != nil && len(resp.Items) > 0 && area != "" {
+		item := resp.Items[len(resp.Items)-1]
+		for _, f := range item.Forecasts {
+			if f.Area == area {
+				candidates = append(candidates, ForecastSlot{
+					Source:      SourceTwoHour,
+					ValidPeriod: item.ValidPeriod,
+					Forecast:    f.Forecast,
+				})
+				break
+			}
+		}
+	}
+
+	if resp := src.TwentyFourHourWeatherForecast; resp != nil && len(resp.Items) > 0 {
+		item := resp.Items[len(resp.Items)-1]
+		temperature := item.General.Temperature
+		if region == "" || region == "national" {
+			candidates = append(candidates, ForecastSlot{
+				Source:      SourceTwentyFourHour,
+				ValidPeriod: item.ValidPeriod,
+				Forecast:    item.General.Forecast,
+				Temperature: &temperature,
+			})
+		} else {
+			for _, period := range item.Periods {
+				candidates = append(candidates, ForecastSlot{
+					Source:      SourceTwentyFourHour,
+					ValidPeriod: period.Time,
+					Forecast:    period.Regions.ByRegion(region),
+					Temperature: &temperature,
+				})
+			}
+		}
+	}
+
+	if resp := src.FourDayWeatherForecast; resp != nil && len(resp.Items) > 0 {
+		item := resp.Items[len(resp.Items)-1]
+		for _, f := range item.Forecasts {
+			start, err := time.ParseInLocation("2006-01-02", f.Date, SGT)
+			if err != nil {
+				continue
+			}
+			temperature := f.Temperature
+			candidates = append(candidates, ForecastSlot{
+				Source: SourceFourDay,
+				ValidPeriod: DatetimeRange{
+					Start: start.Format(time.RFC3339),
+					End:   start.AddDate(0, 0, 1).Format(time.RFC3339),
+				},
+				Forecast:    f.Forecast,
+				Temperature: &temperature,
+			})
+		}
+	}
+
+	// candidates are in order of preference; each one only fills time after the previous slot has ended
+	timeline := []ForecastSlot{}
+	var end time.Time
+	for _, slot := range candidates {
+		slotStart, err := ParseTimestamp(slot.ValidPeriod.Start)
+		if err != nil {
+			continue
+		}
+		slotEnd, err := ParseTimestamp(slot.ValidPeriod.End)
+		if err != nil {
+			continue
+		}
+		if slotStart.Before(end) {
+			slotStart = end
+			slot.ValidPeriod.Start = end.In(SGT).Format(time.RFC3339)
+		}
+		if !slotStart.Before(slotEnd) {
+			continue
+		}
+		timeline = append(timeline, slot)
+		end = slotEnd
+	}
+	return timeline
+}
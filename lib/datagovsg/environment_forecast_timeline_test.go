@@ -0,0 +1,82 @@
+package datagovsg_test
+
+import (
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"testing"
+)
+
+func TestNewForecastTimeline(t *testing.T) {
+	src := datagovsg.ForecastTimelineSources{
+		TwoHourWeatherForecast: &datagovsg.TwoHourWeatherForecastResult{
+			Items: []datagovsg.TwoHourWeatherForecastResultItem{{
+				ValidPeriod: datagovsg.DatetimeRange{Start: "2016-12-01T14:30:00+08:00", End: "2016-12-01T16:30:00+08:00"},
+				Forecasts: []datagovsg.TwoHourWeatherForecast{
+					{Area: "Ang Mo Kio", Forecast: "Partly Cloudy (Day)"},
+					{Area: "Bishan", Forecast: "Thundery Showers"},
+				},
+			}},
+		},
+		TwentyFourHourWeatherForecast: &datagovsg.TwentyFourHourWeatherForecastResult{
+			Items: []datagovsg.TwentyFourHourWeatherForecastResultItem{{
+				ValidPeriod: datagovsg.DatetimeRange{Start: "2016-12-01T12:00:00+08:00", End: "2016-12-02T12:00:00+08:00"},
+				General: datagovsg.GeneralTwentyFourHourWeatherForecast{
+					Forecast:    "Thundery Showers",
+					Temperature: datagovsg.Temperature{Low: 24, High: 32},
+				},
+				Periods: []datagovsg.TwentyFourHourWeatherForecast{
+					{
+						Time:    datagovsg.DatetimeRange{Start: "2016-12-01T12:00:00+08:00", End: "2016-12-01T18:00:00+08:00"},
+						Regions: datagovsg.RegionWeatherForecast{Central: "Thundery Showers"},
+					},
+					{
+						Time:    datagovsg.DatetimeRange{Start: "2016-12-01T18:00:00+08:00", End: "2016-12-02T06:00:00+08:00"},
+						Regions: datagovsg.RegionWeatherForecast{Central: "Partly Cloudy (Night)"},
+					},
+					{
+						Time:    datagovsg.DatetimeRange{Start: "2016-12-02T06:00:00+08:00", End: "2016-12-02T12:00:00+08:00"},
+						Regions: datagovsg.RegionWeatherForecast{Central: "Fair (Day)"},
+					},
+				},
+			}},
+		},
+		FourDayWeatherForecast: &datagovsg.FourDayWeatherForecastResult{
+			Items: []datagovsg.FourDayWeatherForecastResultItem{{
+				Forecasts: []datagovsg.FourDayWeatherForecast{
+					{Date: "2016-12-02", Forecast: "Afternoon thundery showers."},
+					{Date: "2016-12-03", Forecast: "Partly cloudy."},
+				},
+			}},
+		},
+	}
+
+	expected := []struct {
+		source string
+		start  string
+		end    string
+	}{
+		{datagovsg.SourceTwoHour, "2016-12-01T14:30:00+08:00", "2016-12-01T16:30:00+08:00"},
+		{datagovsg.SourceTwentyFourHour, "2016-12-01T16:30:00+08:00", "2016-12-01T18:00:00+08:00"},
+		{datagovsg.SourceTwentyFourHour, "2016-12-01T18:00:00+08:00", "2016-12-02T06:00:00+08:00"},
+		{datagovsg.SourceTwentyFourHour, "2016-12-02T06:00:00+08:00", "2016-12-02T12:00:00+08:00"},
+		{datagovsg.SourceFourDay, "2016-12-02T12:00:00+08:00", "2016-12-03T00:00:00+08:00"},
+		{datagovsg.SourceFourDay, "2016-12-03T00:00:00+08:00", "2016-12-04T00:00:00+08:00"},
+	}
+
+	timeline := datagovsg.NewForecastTimeline("Bishan", "central", src)
+	if len(timeline) != len(expected) {
+		t.Fatalf("expected %v slots, got %v: %+v", len(expected), len(timeline), timeline)
+	}
+	for i, slot := range timeline {
+		e := expected[i]
+		if slot.Source != e.source || slot.ValidPeriod.Start != e.start || slot.ValidPeriod.End != e.end {
+			t.Errorf("slot %v: expected %v %v-%v, got %v %v-%v", i, e.source, e.start, e.end,
+				slot.Source, slot.ValidPeriod.Start, slot.ValidPeriod.End)
+		}
+	}
+	if timeline[0].Forecast != "Thundery Showers" || timeline[0].Temperature != nil {
+		t.Errorf("unexpected two-hour slot: %+v", timeline[0])
+	}
+	if timeline[2].Forecast != "Partly Cloudy (Night)" || timeline[2].Temperature.High != 32 {
+		t.Errorf("unexpected twenty-four hour slot: %+v", timeline[2])
+	}
+}
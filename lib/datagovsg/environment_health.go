@@ -0,0 +1,129 @@
+package datagovsg
+
+// HealthBand is NEA's air quality descriptor band: a PSI band, or a 1-hour PM2.5 band
+type HealthBand string
+
+// PSI bands
+const (
+	HealthBandGood          HealthBand = "GOOD"
+	HealthBandModerate      HealthBand = "MODERATE"
+	HealthBandUnhealthy     HealthBand = "UNHEALTHY"
+	HealthBandVeryUnhealthy HealthBand = "VERY_UNHEALTHY"
+	HealthBandHazardous     HealthBand = "HAZARDOUS"
+)
+
+// 1-hour PM2.5 bands
+const (
+	HealthBandNormal   HealthBand = "NORMAL"
+	HealthBandElevated HealthBand = "ELEVATED"
+	HealthBandHigh     HealthBand = "HIGH"
+	HealthBandVeryHigh HealthBand = "VERY_HIGH"
+)
+
+// HealthAdvisory is NEA's health advisory for a band, by group of persons
+type HealthAdvisory struct {
+	HealthyPersons string `json:"healthy_persons"`
+
+	// Elderly, pregnant women and children
+	Vulnerable string `json:"vulnerable"`
+
+	// Persons with chronic lung disease or heart disease
+	ChronicConditions string `json:"chronic_conditions"`
+}
+
+type healthBreakpoint struct {
+	max  float64
+	band HealthBand
+}
+
+// NEA's PSI descriptor breakpoints
+var psiBreakpoints = []healthBreakpoint{
+	{50, HealthBandGood},
+	{100, HealthBandModerate},
+	{200, HealthBandUnhealthy},
+	{300, HealthBandVeryUnhealthy},
+}
+
+// NEA's 1-hour PM2.5 concentration breakpoints (µg/m³)
+var pm25Breakpoints = []healthBreakpoint{
+	{55, HealthBandNormal},
+	{150, HealthBandElevated},
+	{250, HealthBandHigh},
+}
+
+func classify(value float64, breakpoints []healthBreakpoint, above HealthBand) HealthBand {
+	for _, bp := range breakpoints {
+		if value <= bp.max {
+			return bp.band
+		}
+	}
+	return above
+}
+
+// PSIBand returns the band of a PSI value (or PSI sub-index)
+func PSIBand(psi float64) HealthBand {
+	return classify(psi, psiBreakpoints, HealthBandHazardous)
+}
+
+// PM25Band returns the band of a 1-hour PM2.5 concentration in µg/m³
+func PM25Band(pm25 float64) HealthBand {
+	return classify(pm25, pm25Breakpoints, HealthBandVeryHigh)
+}
+
+// Descriptor returns the band's descriptor as worded by NEA
+func (b HealthBand) Descriptor() string {
+	switch b {
+	case HealthBandGood:
+		return "Good"
+	case HealthBandModerate:
+		return "Moderate"
+	case HealthBandUnhealthy:
+		return "Unhealthy"
+	case HealthBandVeryUnhealthy:
+		return "Very unhealthy"
+	case HealthBandHazardous:
+		return "Hazardous"
+	case HealthBandNormal:
+		return "Normal"
+	case HealthBandElevated:
+		return "Elevated"
+	case HealthBandHigh:
+		return "High"
+	case HealthBandVeryHigh:
+		return "Very high"
+	}
+	return ""
+}
+
+// Advisory returns NEA's health advisory for the band.
+// NEA words its advisories for PSI bands, so 1-hour PM2.5 bands get the advisory of the PSI band they correspond to:
+// normal that of moderate and below, elevated unhealthy, high very unhealthy and very high hazardous.
+func (b HealthBand) Advisory() HealthAdvisory {
+	switch b {
+	case HealthBandGood, HealthBandModerate, HealthBandNormal:
+		return HealthAdvisory{
+			HealthyPersons:    "Normal activities",
+			Vulnerable:        "Normal activities",
+			ChronicConditions: "Normal activities",
+		}
+	case HealthBandUnhealthy, HealthBandElevated:
+		return HealthAdvisory{
+			HealthyPersons:    "Reduce prolonged or strenuous outdoor physical exertion",
+			Vulnerable:        "Minimise prolonged or strenuous outdoor physical exertion",
+			ChronicConditions: "Avoid prolonged or strenuous outdoor physical exertion",
+		}
+	case HealthBandVeryUnhealthy, HealthBandHigh:
+		return HealthAdvisory{
+			HealthyPersons:    "Avoid prolonged or strenuous outdoor physical exertion",
+			Vulnerable:        "Minimise outdoor activity",
+			ChronicConditions: "Avoid outdoor activity",
+		}
+	case HealthBandHazardous, HealthBandVeryHigh:
+		return HealthAdvisory{
+			HealthyPersons:    "Minimise outdoor activity",
+			Vulnerable:        "Avoid outdoor activity",
+			ChronicConditions: "Avoid outdoor activity",
+		}
+	}
+	return HealthAdvisory{}
+}
@@ -0,0 +1,99 @@
+package datagovsg_test
+
+import (
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"testing"
+)
+
+func TestPSIBand(t *testing.T) {
+	tests := []struct {
+		psi      float64
+		expected datagovsg.HealthBand
+	}{
+		{0, datagovsg.HealthBandGood},
+		{50, datagovsg.HealthBandGood},
+		{51, datagovsg.HealthBandModerate},
+		{100, datagovsg.HealthBandModerate},
+		{101, datagovsg.HealthBandUnhealthy},
+		{200, datagovsg.HealthBandUnhealthy},
+		{201, datagovsg.HealthBandVeryUnhealthy},
+		{300, datagovsg.HealthBandVeryUnhealthy},
+		{301, datagovsg.HealthBandHazardous},
+		{401, datagovsg.HealthBandHazardous},
+	}
+	for _, test := range tests {
+		if band := datagovsg.PSIBand(test.psi); band != test.expected {
+			t.Errorf("PSIBand(%v): expected %v, got %v", test.psi, test.expected, band)
+		}
+	}
+}
+
+func TestPM25Band(t *testing.T) {
+	tests := []struct {
+		pm25     float64
+		expected datagovsg.HealthBand
+	}{
+		{0, datagovsg.HealthBandNormal},
+		{12, datagovsg.HealthBandNormal},
+		{55, datagovsg.HealthBandNormal},
+		{56, datagovsg.HealthBandElevated},
+		{150, datagovsg.HealthBandElevated},
+		{151, datagovsg.HealthBandHigh},
+		{250, datagovsg.HealthBandHigh},
+		{251, datagovsg.HealthBandVeryHigh},
+		{500, datagovsg.HealthBandVeryHigh},
+	}
+	for _, test := range tests {
+		if band := datagovsg.PM25Band(test.pm25); band != test.expected {
+			t.Errorf("PM25Band(%v): expected %v, got %v", test.pm25, test.expected, band)
+		}
+	}
+}
+
+func TestHealthBand_Advisory(t *testing.T) {
+	tests := []struct {
+		band       datagovsg.HealthBand
+		descriptor string
+		healthy    string
+		vulnerable string
+		chronic    string
+	}{
+		{datagovsg.HealthBandGood, "Good", "Normal activities", "Normal activities", "Normal activities"},
+		{datagovsg.HealthBandModerate, "Moderate", "Normal activities", "Normal activities", "Normal activities"},
+		{datagovsg.HealthBandUnhealthy, "Unhealthy",
+			"Reduce prolonged or strenuous outdoor physical exertion",
+			"Minimise prolonged or strenuous outdoor physical exertion",
+			"Avoid prolonged or strenuous outdoor physical exertion"},
+		{datagovsg.HealthBandVeryUnhealthy, "Very unhealthy",
+			"Avoid prolonged or strenuous outdoor physical exertion",
+			"Minimise outdoor activity",
+			"Avoid outdoor activity"},
+		{datagovsg.HealthBandHazardous, "Hazardous",
+			"Minimise outdoor activity",
+			"Avoid outdoor activity",
+			"Avoid outdoor activity"},
+		{datagovsg.HealthBandNormal, "Normal", "Normal activities", "Normal activities", "Normal activities"},
+		{datagovsg.HealthBandElevated, "Elevated",
+			"Reduce prolonged or strenuous outdoor physical exertion",
+			"Minimise prolonged or strenuous outdoor physical exertion",
+			"Avoid prolonged or strenuous outdoor physical exertion"},
+		{datagovsg.HealthBandHigh, "High",
+			"Avoid prolonged or strenuous outdoor physical exertion",
+			"Minimise outdoor activity",
+			"Avoid outdoor activity"},
+		{datagovsg.HealthBandVeryHigh, "Very high",
+			"Minimise outdoor activity",
+			"Avoid outdoor activity",
+			"Avoid outdoor activity"},
+		{"", "", "", "", ""},
+	}
+	for _, test := range tests {
+		if d := test.band.Descriptor(); d != test.descriptor {
+			t.Errorf("%v: expected descriptor %q, got %q", test.band, test.descriptor, d)
+		}
+		advisory := test.band.Advisory()
+		if advisory.HealthyPersons != test.healthy || advisory.Vulnerable != test.vulnerable || advisory.ChronicConditions != test.chronic {
+			t.Errorf("%v: unexpected advisory %+v", test.band, advisory)
+		}
+	}
+}
@@ -6,8 +6,14 @@ type PM25ReadingsOptions struct {
 }
 
 type PM25Readings struct {
-	Value int  `json:"value,omitempty"`
-	Area  Area `json:"area,omitempty"`
+	Region string `json:"region,omitempty"`
+	Value  int    `json:"value,omitempty"`
+	Area   Area   `json:"area,omitempty"`
+}
+
+// Band returns the health band of the reading
+func (r PM25Readings) Band() HealthBand {
+	return PM25Band(float64(r.Value))
 }
 
 type PM25ReadingRegions struct {
@@ -17,6 +23,24 @@ type PM25ReadingRegions struct {
 	Central int `json:"central,omitempty"`
 	West    int `json:"west,omitempty"`
 }
+
+// ByRegion returns the reading for the given region name
+func (r PM25ReadingRegions) ByRegion(name string) int {
+	switch name {
+	case "south":
+		return r.South
+	case "north":
+		return r.North
+	case "east":
+		return r.East
+	case "central":
+		return r.Central
+	case "west":
+		return r.West
+	}
+	return 0
+}
+
 type PM25ReadingIntervals struct {
 	PM25OneHourly PM25ReadingRegions `json:"pm25_one_hourly,omitempty"`
 }
@@ -41,6 +65,26 @@ func (resp *PM25ReadingsResult) AreaByName(name string) Area {
 	return Area{}
 }
 
+// PM25Regions lists the regions reported by the PM2.5 endpoint
+var PM25Regions = []string{"south", "north", "east", "central", "west"}
+
+func (resp *PM25ReadingsResult) regionsToGraphQL(r PM25ReadingRegions) PM25ReadingRegionsGraphQL {
+	reading := func(region string) PM25Readings {
+		return PM25Readings{
+			Region: region,
+			Value:  r.ByRegion(region),
+			Area:   resp.AreaByName(region),
+		}
+	}
+	return PM25ReadingRegionsGraphQL{
+		South:   reading("south"),
+		North:   reading("north"),
+		East:    reading("east"),
+		Central: reading("central"),
+		West:    reading("west"),
+	}
+}
+
 func (resp *PM25ReadingsResult) ToGraphQL() interface{} {
 
 	items := []PM25ReadingsResultItemGraphQL{}
@@ -49,28 +93,7 @@ func (resp *PM25ReadingsResult) ToGraphQL() interface{} {
 			UpdateTimestamp: i.UpdateTimestamp,
 			Timestamp:       i.Timestamp,
 			Readings: PM25ReadingIntervalsGraphQL{
-				PM25OneHourly: PM25ReadingRegionsGraphQL{
-					South: PM25Readings{
-						Value: i.Readings.PM25OneHourly.South,
-						Area:  resp.AreaByName("south"),
-					},
-					North: PM25Readings{
-						Value: i.Readings.PM25OneHourly.North,
-						Area:  resp.AreaByName("north"),
-					},
-					East: PM25Readings{
-						Value: i.Readings.PM25OneHourly.East,
-						Area:  resp.AreaByName("east"),
-					},
-					Central: PM25Readings{
-						Value: i.Readings.PM25OneHourly.Central,
-						Area:  resp.AreaByName("central"),
-					},
-					West: PM25Readings{
-						Value: i.Readings.PM25OneHourly.West,
-						Area:  resp.AreaByName("west"),
-					},
-				},
+				PM25OneHourly: resp.regionsToGraphQL(i.Readings.PM25OneHourly),
 			},
 		}
 		items = append(items, item)
@@ -88,6 +111,39 @@ type PM25ReadingRegionsGraphQL struct {
 	Central PM25Readings `json:"central,omitempty"`
 	West    PM25Readings `json:"west,omitempty"`
 }
+
+// ByRegion returns the reading for the given region name
+func (r PM25ReadingRegionsGraphQL) ByRegion(name string) (PM25Readings, bool) {
+	switch name {
+	case "south":
+		return r.South, true
+	case "north":
+		return r.North, true
+	case "east":
+		return r.East, true
+	case "central":
+		return r.Central, true
+	case "west":
+		return r.West, true
+	}
+	return PM25Readings{}, false
+}
+
+// Readings returns the readings for the given regions, or for all regions if none are given.
+// Regions without PM2.5 readings (i.e. national) are skipped.
+func (r PM25ReadingRegionsGraphQL) Readings(regions []string) []PM25Readings {
+	if len(regions) == 0 {
+		regions = PM25Regions
+	}
+	readings := []PM25Readings{}
+	for _, region := range regions {
+		if reading, ok := r.ByRegion(region); ok {
+			readings = append(readings, reading)
+		}
+	}
+	return readings
+}
+
 type PM25ReadingIntervalsGraphQL struct {
 	PM25OneHourly PM25ReadingRegionsGraphQL `json:"pm25_one_hourly,omitempty"`
 }
@@ -6,8 +6,20 @@ type PSIReadingsOptions struct {
 }
 
 type PSIReadings struct {
-	Value float32 `json:"value,omitempty"`
-	Area  Area    `json:"area,omitempty"`
+	Region string  `json:"region,omitempty"`
+	Value  float32 `json:"value,omitempty"`
+	Area   Area    `json:"area,omitempty"`
+
+	// Index is true if Value is a PSI index or sub-index rather than a pollutant concentration
+	Index bool `json:"-"`
+}
+
+// Band returns the health band of the reading, or "" if the reading is a concentration
+func (r PSIReadings) Band() HealthBand {
+	if !r.Index {
+		return ""
+	}
+	return PSIBand(float64(r.Value))
 }
 
 type PSIReadingRegions struct {
@@ -19,6 +31,25 @@ type PSIReadingRegions struct {
 	West     float32 `json:"west,omitempty"`
 }
 
+// ByRegion returns the reading for the given region name
+func (r PSIReadingRegions) ByRegion(name string) float32 {
+	switch name {
+	case "national":
+		return r.National
+	case "south":
+		return r.South
+	case "north":
+		return r.North
+	case "east":
+		return r.East
+	case "central":
+		return r.Central
+	case "west":
+		return r.West
+	}
+	return 0
+}
+
 type PSIReadingIntervals struct {
 	PSITwentyFourHourly  PSIReadingRegions `json:"psi_twenty_four_hourly,omitempty"`
 	PM10TwentyFourHourly PSIReadingRegions `json:"pm10_twenty_four_hourly,omitempty"`
@@ -56,354 +87,92 @@ func (resp *PSIReadingsResult) AreaByName(name string) Area {
 	return Area{}
 }
 
-func (resp *PSIReadingsResult) ToGraphQL() interface{} {
+// PSIMetric is one of the readings reported by the PSI endpoint
+type PSIMetric struct {
+	// Name is the metric's field name, e.g. psi_twenty_four_hourly
+	Name string
+
+	// Index is true if the metric is a PSI index or sub-index rather than a pollutant concentration
+	Index bool
 
-	// I know this is extremely ripe for some refactoring work, but patience, my young grasshoppa
+	readings func(*PSIReadingIntervals) *PSIReadingRegions
+	graphQL  func(*PSIReadingIntervalsGraphQL) *PSIReadingRegionsGraphQL
+}
+
+// PSIMetrics lists the metrics reported by the PSI endpoint
+var PSIMetrics = []PSIMetric{
+	{"psi_twenty_four_hourly", true,
+		func(i *PSIReadingIntervals) *PSIReadingRegions { return &i.PSITwentyFourHourly },
+		func(i *PSIReadingIntervalsGraphQL) *PSIReadingRegionsGraphQL { return &i.PSITwentyFourHourly }},
+	{"pm10_twenty_four_hourly", false,
+		func(i *PSIReadingIntervals) *PSIReadingRegions { return &i.PM10TwentyFourHourly },
+		func(i *PSIReadingIntervalsGraphQL) *PSIReadingRegionsGraphQL { return &i.PM10TwentyFourHourly }},
+	{"pm10_sub_index", true,
+		func(i *PSIReadingIntervals) *PSIReadingRegions { return &i.PM10SubIndex },
+		func(i *PSIReadingIntervalsGraphQL) *PSIReadingRegionsGraphQL { return &i.PM10SubIndex }},
+	{"pm25_twenty_four_hourly", false,
+		func(i *PSIReadingIntervals) *PSIReadingRegions { return &i.PM25TwentyFourHourly },
+		func(i *PSIReadingIntervalsGraphQL) *PSIReadingRegionsGraphQL { return &i.PM25TwentyFourHourly }},
+	{"psi_three_hourly", true,
+		func(i *PSIReadingIntervals) *PSIReadingRegions { return &i.PSIThreeHourly },
+		func(i *PSIReadingIntervalsGraphQL) *PSIReadingRegionsGraphQL { return &i.PSIThreeHourly }},
+	{"so2_twenty_four_hourly", false,
+		func(i *PSIReadingIntervals) *PSIReadingRegions { return &i.SO2TwentyFourHourly },
+		func(i *PSIReadingIntervalsGraphQL) *PSIReadingRegionsGraphQL { return &i.SO2TwentyFourHourly }},
+	{"o3_sub_index", true,
+		func(i *PSIReadingIntervals) *PSIReadingRegions { return &i.O3SubIndex },
+		func(i *PSIReadingIntervalsGraphQL) *PSIReadingRegionsGraphQL { return &i.O3SubIndex }},
+	{"no2_one_hour_max", false,
+		func(i *PSIReadingIntervals) *PSIReadingRegions { return &i.NO2OneHourMax },
+		func(i *PSIReadingIntervalsGraphQL) *PSIReadingRegionsGraphQL { return &i.NO2OneHourMax }},
+	{"so2_sub_index", true,
+		func(i *PSIReadingIntervals) *PSIReadingRegions { return &i.SO2SubIndex },
+		func(i *PSIReadingIntervalsGraphQL) *PSIReadingRegionsGraphQL { return &i.SO2SubIndex }},
+	{"pm25_sub_index", true,
+		func(i *PSIReadingIntervals) *PSIReadingRegions { return &i.PM2SubIndex },
+		func(i *PSIReadingIntervalsGraphQL) *PSIReadingRegionsGraphQL { return &i.PM2SubIndex }},
+	{"co_eight_hour_max", false,
+		func(i *PSIReadingIntervals) *PSIReadingRegions { return &i.COEightHourMax },
+		func(i *PSIReadingIntervalsGraphQL) *PSIReadingRegionsGraphQL { return &i.COEightHourMax }},
+	{"co_sub_index", true,
+		func(i *PSIReadingIntervals) *PSIReadingRegions { return &i.COSubIndex },
+		func(i *PSIReadingIntervalsGraphQL) *PSIReadingRegionsGraphQL { return &i.COSubIndex }},
+	{"o3_eight_hour_max", false,
+		func(i *PSIReadingIntervals) *PSIReadingRegions { return &i.O3EightHourMax },
+		func(i *PSIReadingIntervalsGraphQL) *PSIReadingRegionsGraphQL { return &i.O3EightHourMax }},
+}
+
+// PSIRegions lists the regions reported by the PSI endpoint
+var PSIRegions = []string{"national", "south", "north", "east", "central", "west"}
+
+func (resp *PSIReadingsResult) regionsToGraphQL(r PSIReadingRegions, index bool) PSIReadingRegionsGraphQL {
+	reading := func(region string) PSIReadings {
+		return PSIReadings{
+			Region: region,
+			Value:  r.ByRegion(region),
+			Area:   resp.AreaByName(region),
+			Index:  index,
+		}
+	}
+	return PSIReadingRegionsGraphQL{
+		National: reading("national"),
+		South:    reading("south"),
+		North:    reading("north"),
+		East:     reading("east"),
+		Central:  reading("central"),
+		West:     reading("west"),
+	}
+}
+
+func (resp *PSIReadingsResult) ToGraphQL() interface{} {
 	items := []PSIReadingsResultItemGraphQL{}
 	for _, i := range resp.Items {
 		item := PSIReadingsResultItemGraphQL{
 			UpdateTimestamp: i.UpdateTimestamp,
 			Timestamp:       i.Timestamp,
-			Readings: PSIReadingIntervalsGraphQL{
-				PSITwentyFourHourly: PSIReadingRegionsGraphQL{
-					National: PSIReadings{
-						Value: i.Readings.PSITwentyFourHourly.National,
-						Area:  resp.AreaByName("national"),
-					},
-					South: PSIReadings{
-						Value: i.Readings.PSITwentyFourHourly.South,
-						Area:  resp.AreaByName("south"),
-					},
-					North: PSIReadings{
-						Value: i.Readings.PSITwentyFourHourly.North,
-						Area:  resp.AreaByName("north"),
-					},
-					East: PSIReadings{
-						Value: i.Readings.PSITwentyFourHourly.East,
-						Area:  resp.AreaByName("east"),
-					},
-					Central: PSIReadings{
-						Value: i.Readings.PSITwentyFourHourly.Central,
-						Area:  resp.AreaByName("central"),
-					},
-					West: PSIReadings{
-						Value: i.Readings.PSITwentyFourHourly.West,
-						Area:  resp.AreaByName("west"),
-					},
-				},
-				PM10TwentyFourHourly: PSIReadingRegionsGraphQL{
-					National: PSIReadings{
-						Value: i.Readings.PM10TwentyFourHourly.National,
-						Area:  resp.AreaByName("national"),
-					},
-					South: PSIReadings{
-						Value: i.Readings.PM10TwentyFourHourly.South,
-						Area:  resp.AreaByName("south"),
-					},
-					North: PSIReadings{
-						Value: i.Readings.PM10TwentyFourHourly.North,
-						Area:  resp.AreaByName("north"),
-					},
-					East: PSIReadings{
-						Value: i.Readings.PM10TwentyFourHourly.East,
-						Area:  resp.AreaByName("east"),
-					},
-					Central: PSIReadings{
-						Value: i.Readings.PM10TwentyFourHourly.Central,
-						Area:  resp.AreaByName("central"),
-					},
-					West: PSIReadings{
-						Value: i.Readings.PM10TwentyFourHourly.West,
-						Area:  resp.AreaByName("west"),
-					},
-				},
-				PM10SubIndex: PSIReadingRegionsGraphQL{
-					National: PSIReadings{
-						Value: i.Readings.PM10SubIndex.National,
-						Area:  resp.AreaByName("national"),
-					},
-					South: PSIReadings{
-						Value: i.Readings.PM10SubIndex.South,
-						Area:  resp.AreaByName("south"),
-					},
-					North: PSIReadings{
-						Value: i.Readings.PM10SubIndex.North,
-						Area:  resp.AreaByName("north"),
-					},
-					East: PSIReadings{
-						Value: i.Readings.PM10SubIndex.East,
-						Area:  resp.AreaByName("east"),
-					},
-					Central: PSIReadings{
-						Value: i.Readings.PM10SubIndex.Central,
-						Area:  resp.AreaByName("central"),
-					},
-					West: PSIReadings{
-						Value: i.Readings.PM10SubIndex.West,
-						Area:  resp.AreaByName("west"),
-					},
-				},
-				PM25TwentyFourHourly: PSIReadingRegionsGraphQL{
-					National: PSIReadings{
-						Value: i.Readings.PM25TwentyFourHourly.National,
-						Area:  resp.AreaByName("national"),
-					},
-					South: PSIReadings{
-						Value: i.Readings.PM25TwentyFourHourly.South,
-						Area:  resp.AreaByName("south"),
-					},
-					North: PSIReadings{
-						Value: i.Readings.PM25TwentyFourHourly.North,
-						Area:  resp.AreaByName("north"),
-					},
-					East: PSIReadings{
-						Value: i.Readings.PM25TwentyFourHourly.East,
-						Area:  resp.AreaByName("east"),
-					},
-					Central: PSIReadings{
-						Value: i.Readings.PM25TwentyFourHourly.Central,
-						Area:  resp.AreaByName("central"),
-					},
-					West: PSIReadings{
-						Value: i.Readings.PM25TwentyFourHourly.West,
-						Area:  resp.AreaByName("west"),
-					},
-				},
-				PSIThreeHourly: PSIReadingRegionsGraphQL{
-					National: PSIReadings{
-						Value: i.Readings.PSIThreeHourly.National,
-						Area:  resp.AreaByName("national"),
-					},
-					South: PSIReadings{
-						Value: i.Readings.PSIThreeHourly.South,
-						Area:  resp.AreaByName("south"),
-					},
-					North: PSIReadings{
-						Value: i.Readings.PSIThreeHourly.North,
-						Area:  resp.AreaByName("north"),
-					},
-					East: PSIReadings{
-						Value: i.Readings.PSIThreeHourly.East,
-						Area:  resp.AreaByName("east"),
-					},
-					Central: PSIReadings{
-						Value: i.Readings.PSIThreeHourly.Central,
-						Area:  resp.AreaByName("central"),
-					},
-					West: PSIReadings{
-						Value: i.Readings.PSIThreeHourly.West,
-						Area:  resp.AreaByName("west"),
-					},
-				},
-				SO2TwentyFourHourly: PSIReadingRegionsGraphQL{
-					National: PSIReadings{
-						Value: i.Readings.SO2TwentyFourHourly.National,
-						Area:  resp.AreaByName("national"),
-					},
-					South: PSIReadings{
-						Value: i.Readings.SO2TwentyFourHourly.South,
-						Area:  resp.AreaByName("south"),
-					},
-					North: PSIReadings{
-						Value: i.Readings.SO2TwentyFourHourly.North,
-						Area:  resp.AreaByName("north"),
-					},
-					East: PSIReadings{
-						Value: i.Readings.SO2TwentyFourHourly.East,
-						Area:  resp.AreaByName("east"),
-					},
-					Central: PSIReadings{
-						Value: i.Readings.SO2TwentyFourHourly.Central,
-						Area:  resp.AreaByName("central"),
-					},
-					West: PSIReadings{
-						Value: i.Readings.SO2TwentyFourHourly.West,
-						Area:  resp.AreaByName("west"),
-					},
-				},
-				O3SubIndex: PSIReadingRegionsGraphQL{
-					National: PSIReadings{
-						Value: i.Readings.O3SubIndex.National,
-						Area:  resp.AreaByName("national"),
-					},
-					South: PSIReadings{
-						Value: i.Readings.O3SubIndex.South,
-						Area:  resp.AreaByName("south"),
-					},
-					North: PSIReadings{
-						Value: i.Readings.O3SubIndex.North,
-						Area:  resp.AreaByName("north"),
-					},
-					East: PSIReadings{
-						Value: i.Readings.O3SubIndex.East,
-						Area:  resp.AreaByName("east"),
-					},
-					Central: PSIReadings{
-						Value: i.Readings.O3SubIndex.Central,
-						Area:  resp.AreaByName("central"),
-					},
-					West: PSIReadings{
-						Value: i.Readings.O3SubIndex.West,
-						Area:  resp.AreaByName("west"),
-					},
-				},
-				NO2OneHourMax: PSIReadingRegionsGraphQL{
-					National: PSIReadings{
-						Value: i.Readings.NO2OneHourMax.National,
-						Area:  resp.AreaByName("national"),
-					},
-					South: PSIReadings{
-						Value: i.Readings.NO2OneHourMax.South,
-						Area:  resp.AreaByName("south"),
-					},
-					North: PSIReadings{
-						Value: i.Readings.NO2OneHourMax.North,
-						Area:  resp.AreaByName("north"),
-					},
-					East: PSIReadings{
-						Value: i.Readings.NO2OneHourMax.East,
-						Area:  resp.AreaByName("east"),
-					},
-					Central: PSIReadings{
-						Value: i.Readings.NO2OneHourMax.Central,
-						Area:  resp.AreaByName("central"),
-					},
-					West: PSIReadings{
-						Value: i.Readings.NO2OneHourMax.West,
-						Area:  resp.AreaByName("west"),
-					},
-				},
-				SO2SubIndex: PSIReadingRegionsGraphQL{
-					National: PSIReadings{
-						Value: i.Readings.SO2SubIndex.National,
-						Area:  resp.AreaByName("national"),
-					},
-					South: PSIReadings{
-						Value: i.Readings.SO2SubIndex.South,
-						Area:  resp.AreaByName("south"),
-					},
-					North: PSIReadings{
-						Value: i.Readings.SO2SubIndex.North,
-						Area:  resp.AreaByName("north"),
-					},
-					East: PSIReadings{
-						Value: i.Readings.SO2SubIndex.East,
-						Area:  resp.AreaByName("east"),
-					},
-					Central: PSIReadings{
-						Value: i.Readings.SO2SubIndex.Central,
-						Area:  resp.AreaByName("central"),
-					},
-					West: PSIReadings{
-						Value: i.Readings.SO2SubIndex.West,
-						Area:  resp.AreaByName("west"),
-					},
-				},
-				PM2SubIndex: PSIReadingRegionsGraphQL{
-					National: PSIReadings{
-						Value: i.Readings.PM2SubIndex.National,
-						Area:  resp.AreaByName("national"),
-					},
-					South: PSIReadings{
-						Value: i.Readings.PM2SubIndex.South,
-						Area:  resp.AreaByName("south"),
-					},
-					North: PSIReadings{
-						Value: i.Readings.PM2SubIndex.North,
-						Area:  resp.AreaByName("north"),
-					},
-					East: PSIReadings{
-						Value: i.Readings.PM2SubIndex.East,
-						Area:  resp.AreaByName("east"),
-					},
-					Central: PSIReadings{
-						Value: i.Readings.PM2SubIndex.Central,
-						Area:  resp.AreaByName("central"),
-					},
-					West: PSIReadings{
-						Value: i.Readings.PM2SubIndex.West,
-						Area:  resp.AreaByName("west"),
-					},
-				},
-				COEightHourMax: PSIReadingRegionsGraphQL{
-					National: PSIReadings{
-						Value: i.Readings.COEightHourMax.National,
-						Area:  resp.AreaByName("national"),
-					},
-					South: PSIReadings{
-						Value: i.Readings.COEightHourMax.South,
-						Area:  resp.AreaByName("south"),
-					},
-					North: PSIReadings{
-						Value: i.Readings.COEightHourMax.North,
-						Area:  resp.AreaByName("north"),
-					},
-					East: PSIReadings{
-						Value: i.Readings.COEightHourMax.East,
-						Area:  resp.AreaByName("east"),
-					},
-					Central: PSIReadings{
-						Value: i.Readings.COEightHourMax.Central,
-						Area:  resp.AreaByName("central"),
-					},
-					West: PSIReadings{
-						Value: i.Readings.COEightHourMax.West,
-						Area:  resp.AreaByName("west"),
-					},
-				},
-				COSubIndex: PSIReadingRegionsGraphQL{
-					National: PSIReadings{
-						Value: i.Readings.COSubIndex.National,
-						Area:  resp.AreaByName("national"),
-					},
-					South: PSIReadings{
-						Value: i.Readings.COSubIndex.South,
-						Area:  resp.AreaByName("south"),
-					},
-					North: PSIReadings{
-						Value: i.Readings.COSubIndex.North,
-						Area:  resp.AreaByName("north"),
-					},
-					East: PSIReadings{
-						Value: i.Readings.COSubIndex.East,
-						Area:  resp.AreaByName("east"),
-					},
-					Central: PSIReadings{
-						Value: i.Readings.COSubIndex.Central,
-						Area:  resp.AreaByName("central"),
-					},
-					West: PSIReadings{
-						Value: i.Readings.COSubIndex.West,
-						Area:  resp.AreaByName("west"),
-					},
-				},
-				O3EightHourMax: PSIReadingRegionsGraphQL{
-					National: PSIReadings{
-						Value: i.Readings.O3EightHourMax.National,
-						Area:  resp.AreaByName("national"),
-					},
-					South: PSIReadings{
-						Value: i.Readings.O3EightHourMax.South,
-						Area:  resp.AreaByName("south"),
-					},
-					North: PSIReadings{
-						Value: i.Readings.O3EightHourMax.North,
-						Area:  resp.AreaByName("north"),
-					},
-					East: PSIReadings{
-						Value: i.Readings.O3EightHourMax.East,
-						Area:  resp.AreaByName("east"),
-					},
-					Central: PSIReadings{
-						Value: i.Readings.O3EightHourMax.Central,
-						Area:  resp.AreaByName("central"),
-					},
-					West: PSIReadings{
-						Value: i.Readings.O3EightHourMax.West,
-						Area:  resp.AreaByName("west"),
-					},
-				},
-			},
+		}
+		for _, metric := range PSIMetrics {
+			*metric.graphQL(&item.Readings) = resp.regionsToGraphQL(*metric.readings(&i.Readings), metric.Index)
 		}
 		items = append(items, item)
 	}
@@ -421,6 +190,40 @@ type PSIReadingRegionsGraphQL struct {
 	Central  PSIReadings `json:"central,omitempty"`
 	West     PSIReadings `json:"west,omitempty"`
 }
+
+// ByRegion returns the reading for the given region name
+func (r PSIReadingRegionsGraphQL) ByRegion(name string) (PSIReadings, bool) {
+	switch name {
+	case "national":
+		return r.National, true
+	case "south":
+		return r.South, true
+	case "north":
+		return r.North, true
+	case "east":
+		return r.East, true
+	case "central":
+		return r.Central, true
+	case "west":
+		return r.West, true
+	}
+	return PSIReadings{}, false
+}
+
+// Readings returns the readings for the given regions, or for all regions if none are given
+func (r PSIReadingRegionsGraphQL) Readings(regions []string) []PSIReadings {
+	if len(regions) == 0 {
+		regions = PSIRegions
+	}
+	readings := []PSIReadings{}
+	for _, region := range regions {
+		if reading, ok := r.ByRegion(region); ok {
+			readings = append(readings, reading)
+		}
+	}
+	return readings
+}
+
 type PSIReadingIntervalsGraphQL struct {
 	PSITwentyFourHourly  PSIReadingRegionsGraphQL `json:"psi_twenty_four_hourly,omitempty"`
 	PM10TwentyFourHourly PSIReadingRegionsGraphQL `json:"pm10_twenty_four_hourly,omitempty"`
@@ -436,6 +239,17 @@ type PSIReadingIntervalsGraphQL struct {
 	COSubIndex           PSIReadingRegionsGraphQL `json:"co_sub_index,omitempty"`
 	O3EightHourMax       PSIReadingRegionsGraphQL `json:"o3_eight_hour_max,omitempty"`
 }
+
+// ByMetric returns the readings of the metric with the given name
+func (i PSIReadingIntervalsGraphQL) ByMetric(name string) (PSIReadingRegionsGraphQL, bool) {
+	for _, metric := range PSIMetrics {
+		if metric.Name == name {
+			return *metric.graphQL(&i), true
+		}
+	}
+	return PSIReadingRegionsGraphQL{}, false
+}
+
 type PSIReadingsResultItemGraphQL struct {
 	UpdateTimestamp string                     `json:"update_timestamp,omitempty"`
 	Timestamp       string                     `json:"timestamp,omitempty"`
@@ -0,0 +1,131 @@
+package datagovsg_test
+
+import (
+	"encoding/json"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"reflect"
+	"testing"
+)
+
+// psiSample are the readings in sample/environment_psi.json, by metric, in the order of datagovsg.PSIRegions.
+// index is true for the metrics that were banded before ToGraphQL was table-driven.
+var psiSample = []struct {
+	metric string
+	index  bool
+	values [6]float32
+}{
+	{"psi_twenty_four_hourly", true, [6]float32{59, 38, 54, 34, 29, 59}},
+	{"pm10_twenty_four_hourly", false, [6]float32{35, 21, 27, 16, 14, 35}},
+	{"pm10_sub_index", true, [6]float32{35, 21, 27, 16, 14, 35}},
+	{"pm25_twenty_four_hourly", false, [6]float32{19, 9, 14, 8, 7, 19}},
+	{"psi_three_hourly", true, [6]float32{52, 33, 54, 56, 33, 60}},
+	{"so2_twenty_four_hourly", false, [6]float32{15, 10, 10, 8, 6, 15}},
+	{"o3_sub_index", true, [6]float32{9, 2, 9, 1, 3, 3}},
+	{"no2_one_hour_max", false, [6]float32{31, 16, 29, 31, 27, 24}},
+	{"so2_sub_index", true, [6]float32{10, 6, 6, 5, 4, 10}},
+	{"pm25_sub_index", true, [6]float32{59, 38, 54, 34, 29, 59}},
+	{"co_eight_hour_max", false, [6]float32{1.12, 0.44, 0.73, 0.42, 1.01, 1.12}},
+	{"co_sub_index", true, [6]float32{11, 4, 7, 4, 10, 11}},
+	{"o3_eight_hour_max", false, [6]float32{21, 4, 21, 3, 6, 8}},
+}
+
+func TestPSIMetrics(t *testing.T) {
+	if len(datagovsg.PSIMetrics) != len(psiSample) {
+		t.Fatalf("expected %v metrics, got %v", len(psiSample), len(datagovsg.PSIMetrics))
+	}
+	for i, metric := range datagovsg.PSIMetrics {
+		if metric.Name != psiSample[i].metric || metric.Index != psiSample[i].index {
+			t.Errorf("metric %v: expected %v (index %v), got %v (index %v)",
+				i, psiSample[i].metric, psiSample[i].index, metric.Name, metric.Index)
+		}
+	}
+}
+
+func TestPSIReadingsResult_ToGraphQL(t *testing.T) {
+	resp := &datagovsg.PSIReadingsResult{}
+	loadSample(t, "environment_psi", resp)
+	result, ok := resp.ToGraphQL().(datagovsg.PSIReadingsResultGraphQL)
+	if !ok || len(result.Items) != 1 || result.APIInfo.Status != "healthy" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	item := result.Items[0]
+	if item.Timestamp != resp.Items[0].Timestamp || item.UpdateTimestamp != resp.Items[0].UpdateTimestamp {
+		t.Errorf("expected the item's timestamps to be kept, got %v and %v", item.Timestamp, item.UpdateTimestamp)
+	}
+
+	// the readings by their JSON field names, independently of the PSIMetrics accessors
+	data, _ := json.Marshal(item.Readings)
+	byField := map[string]map[string]datagovsg.PSIReadings{}
+	if err := json.Unmarshal(data, &byField); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, sample := range psiSample {
+		regions, ok := item.Readings.ByMetric(sample.metric)
+		if !ok {
+			t.Errorf("%v: no readings", sample.metric)
+			continue
+		}
+		readings := regions.Readings(nil)
+		if len(readings) != len(datagovsg.PSIRegions) {
+			t.Errorf("%v: expected %v readings, got %v", sample.metric, len(datagovsg.PSIRegions), len(readings))
+			continue
+		}
+		for j, region := range datagovsg.PSIRegions {
+			expected := datagovsg.PSIReadings{
+				Region: region,
+				Value:  sample.values[j],
+				Area:   resp.AreaByName(region),
+				Index:  sample.index,
+			}
+			if reading, _ := regions.ByRegion(region); reading != expected {
+				t.Errorf("%v %v: expected %+v, got %+v", sample.metric, region, expected, reading)
+			}
+			if readings[j] != expected {
+				t.Errorf("%v: expected reading %v to be %+v, got %+v", sample.metric, j, expected, readings[j])
+			}
+			if field := byField[sample.metric][region]; field.Value != expected.Value || field.Region != region || field.Area != expected.Area {
+				t.Errorf("%v %v: expected the %v field to have %v, got %+v", sample.metric, region, sample.metric, expected.Value, field)
+			}
+
+			band := datagovsg.HealthBand("")
+			if sample.index {
+				band = datagovsg.PSIBand(float64(sample.values[j]))
+			}
+			if b := readings[j].Band(); b != band {
+				t.Errorf("%v %v: expected band %q, got %q", sample.metric, region, band, b)
+			}
+		}
+	}
+
+	if _, ok := item.Readings.ByMetric("o2_twenty_four_hourly"); ok {
+		t.Error("expected no readings for an unknown metric")
+	}
+	if band := item.Readings.PSIThreeHourly.West.Band(); band != datagovsg.HealthBandModerate {
+		t.Errorf("expected the west 3-hour PSI of 60 to be moderate, got %q", band)
+	}
+}
+
+func TestPSIReadingRegionsGraphQL_Readings(t *testing.T) {
+	resp := &datagovsg.PSIReadingsResult{}
+	loadSample(t, "environment_psi", resp)
+	regions := resp.ToGraphQL().(datagovsg.PSIReadingsResultGraphQL).Items[0].Readings.PSITwentyFourHourly
+
+	tests := []struct {
+		regions  []string
+		expected []float32
+	}{
+		{nil, []float32{59, 38, 54, 34, 29, 59}},
+		{[]string{"west", "south"}, []float32{59, 38}},
+		{[]string{"central", "nowhere", "central"}, []float32{29, 29}},
+	}
+	for _, test := range tests {
+		values := []float32{}
+		for _, reading := range regions.Readings(test.regions) {
+			values = append(values, reading.Value)
+		}
+		if !reflect.DeepEqual(values, test.expected) {
+			t.Errorf("%v: expected %v, got %v", test.regions, test.expected, values)
+		}
+	}
+}
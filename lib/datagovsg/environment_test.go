@@ -0,0 +1,53 @@
+package datagovsg_test
+
+import (
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"math"
+	"testing"
+)
+
+func TestLocation_DistanceToSegment(t *testing.T) {
+	// a west-east segment along latitude 1.30, a little over 11km long
+	a := datagovsg.Location{Latitude: 1.30, Longitude: 103.80}
+	b := datagovsg.Location{Latitude: 1.30, Longitude: 103.90}
+	tests := []struct {
+		name    string
+		loc     datagovsg.Location
+		a, b    datagovsg.Location
+		nearest datagovsg.Location
+	}{
+		{"before the segment", datagovsg.Location{Latitude: 1.31, Longitude: 103.70}, a, b, a},
+		{"inside the segment", datagovsg.Location{Latitude: 1.31, Longitude: 103.85}, a, b, datagovsg.Location{Latitude: 1.30, Longitude: 103.85}},
+		{"past the segment", datagovsg.Location{Latitude: 1.29, Longitude: 104.00}, a, b, b},
+		{"reversed segment", datagovsg.Location{Latitude: 1.29, Longitude: 103.85}, b, a, datagovsg.Location{Latitude: 1.30, Longitude: 103.85}},
+		{"on the segment", datagovsg.Location{Latitude: 1.30, Longitude: 103.82}, a, b, datagovsg.Location{Latitude: 1.30, Longitude: 103.82}},
+		{"zero-length segment", datagovsg.Location{Latitude: 1.31, Longitude: 103.85}, a, a, a},
+	}
+	for _, test := range tests {
+		expected := test.loc.DistanceTo(test.nearest)
+		if distance := test.loc.DistanceToSegment(test.a, test.b); math.Abs(distance-expected) > 0.5 {
+			t.Errorf("%v: expected %.1fm, got %.1fm", test.name, expected, distance)
+		}
+	}
+}
+
+func TestLocation_DistanceToLine(t *testing.T) {
+	loc := datagovsg.Location{Latitude: 1.31, Longitude: 103.85}
+	line := []datagovsg.Location{
+		{Latitude: 1.30, Longitude: 103.80},
+		{Latitude: 1.30, Longitude: 103.84},
+		{Latitude: 1.35, Longitude: 103.84},
+	}
+
+	// the nearest segment is the northbound one, 0.01° of longitude away
+	expected := loc.DistanceTo(datagovsg.Location{Latitude: 1.31, Longitude: 103.84})
+	if distance := loc.DistanceToLine(line); math.Abs(distance-expected) > 0.5 {
+		t.Errorf("expected %.1fm to the line, got %.1fm", expected, distance)
+	}
+	if distance := loc.DistanceToLine(line[:1]); distance != loc.DistanceTo(line[0]) {
+		t.Errorf("expected the distance to a single point, got %.1fm", distance)
+	}
+	if distance := loc.DistanceToLine(nil); !math.IsInf(distance, 1) {
+		t.Errorf("expected an infinite distance to an empty line, got %v", distance)
+	}
+}
@@ -0,0 +1,66 @@
+package datagovsg
+
+import (
+	"strings"
+)
+
+// Temperature units. data.gov.sg reports temperatures in degrees Celsius.
+const (
+	Celsius    = "CELSIUS"
+	Fahrenheit = "FAHRENHEIT"
+	Kelvin     = "KELVIN"
+)
+
+// Speed units. data.gov.sg reports wind speeds in km/h.
+const (
+	KMH   = "KMH"
+	MS    = "MS"
+	MPH   = "MPH"
+	Knots = "KNOTS"
+)
+
+// ConvertTemperature converts a temperature in degrees Celsius to the given unit
+func ConvertTemperature(celsius float64, unit string) float64 {
+	switch unit {
+	case Fahrenheit:
+		return celsius*9/5 + 32
+	case Kelvin:
+		return celsius + 273.15
+	}
+	return celsius
+}
+
+// ConvertSpeed converts a speed in km/h to the given unit
+func ConvertSpeed(kmh float64, unit string) float64 {
+	switch unit {
+	case MS:
+		return kmh / 3.6
+	case MPH:
+		return kmh / 1.609344
+	case Knots:
+		return kmh / 1.852
+	}
+	return kmh
+}
+
+var compassPoints = []string{
+	"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+	"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+}
+
+// CompassDegrees returns the bearing in degrees clockwise from north of a 16-point compass direction (e.g. "NNE").
+// ok is false for anything else, e.g. "VARIABLE".
+func CompassDegrees(direction string) (degrees float64, ok bool) {
+	d := strings.ToUpper(strings.TrimSpace(direction))
+	for i, point := range compassPoints {
+		if d == point {
+			return float64(i) * 22.5, true
+		}
+	}
+	return 0, false
+}
+
+// DirectionDegrees returns the wind direction in degrees, or false if the direction is not a compass point
+func (w Wind) DirectionDegrees() (float64, bool) {
+	return CompassDegrees(w.Direction)
+}
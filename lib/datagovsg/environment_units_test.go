@@ -0,0 +1,79 @@
+package datagovsg_test
+
+import (
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"math"
+	"testing"
+)
+
+func TestConvertTemperature(t *testing.T) {
+	tests := []struct {
+		celsius  float64
+		unit     string
+		expected float64
+	}{
+		{31, datagovsg.Celsius, 31},
+		{31, "", 31},
+		{0, datagovsg.Fahrenheit, 32},
+		{100, datagovsg.Fahrenheit, 212},
+		{-40, datagovsg.Fahrenheit, -40},
+		{25, datagovsg.Fahrenheit, 77},
+		{0, datagovsg.Kelvin, 273.15},
+		{-273.15, datagovsg.Kelvin, 0},
+	}
+	for _, test := range tests {
+		if result := datagovsg.ConvertTemperature(test.celsius, test.unit); math.Abs(result-test.expected) > 1e-9 {
+			t.Errorf("ConvertTemperature(%v, %q): expected %v, got %v", test.celsius, test.unit, test.expected, result)
+		}
+	}
+}
+
+func TestConvertSpeed(t *testing.T) {
+	tests := []struct {
+		kmh      float64
+		unit     string
+		expected float64
+	}{
+		{20, datagovsg.KMH, 20},
+		{20, "", 20},
+		{36, datagovsg.MS, 10},
+		{1.609344, datagovsg.MPH, 1},
+		{100, datagovsg.MPH, 62.13711922},
+		{1.852, datagovsg.Knots, 1},
+		{37.04, datagovsg.Knots, 20},
+		{0, datagovsg.Knots, 0},
+	}
+	for _, test := range tests {
+		if result := datagovsg.ConvertSpeed(test.kmh, test.unit); math.Abs(result-test.expected) > 1e-6 {
+			t.Errorf("ConvertSpeed(%v, %q): expected %v, got %v", test.kmh, test.unit, test.expected, result)
+		}
+	}
+}
+
+func TestCompassDegrees(t *testing.T) {
+	tests := []struct {
+		direction string
+		degrees   float64
+		ok        bool
+	}{
+		{"N", 0, true},
+		{"NNE", 22.5, true},
+		{"NE", 45, true},
+		{"E", 90, true},
+		{"SSE", 157.5, true},
+		{"S", 180, true},
+		{"WSW", 247.5, true},
+		{"W", 270, true},
+		{"NNW", 337.5, true},
+		{" sw ", 225, true},
+		{"VARIABLE", 0, false},
+		{"", 0, false},
+		{"NORTH", 0, false},
+	}
+	for _, test := range tests {
+		degrees, ok := datagovsg.CompassDegrees(test.direction)
+		if degrees != test.degrees || ok != test.ok {
+			t.Errorf("CompassDegrees(%q): expected %v, %v, got %v, %v", test.direction, test.degrees, test.ok, degrees, ok)
+		}
+	}
+}
@@ -24,3 +24,85 @@ type UVIndexReadingsResult struct {
 func (resp *UVIndexReadingsResult) ToGraphQL() interface{} {
 	return resp
 }
+
+// UVCategory is the WHO exposure category of a UV index value
+type UVCategory string
+
+const (
+	UVCategoryLow      UVCategory = "LOW"
+	UVCategoryModerate UVCategory = "MODERATE"
+	UVCategoryHigh     UVCategory = "HIGH"
+	UVCategoryVeryHigh UVCategory = "VERY_HIGH"
+	UVCategoryExtreme  UVCategory = "EXTREME"
+)
+
+// UVCategories lists the categories from lowest to highest
+var UVCategories = []UVCategory{UVCategoryLow, UVCategoryModerate, UVCategoryHigh, UVCategoryVeryHigh, UVCategoryExtreme}
+
+// UVIndexCategory returns the exposure category of a UV index value
+func UVIndexCategory(value int) UVCategory {
+	category := UVCategoryLow
+	for _, c := range UVCategories {
+		if value >= c.Min() {
+			category = c
+		}
+	}
+	return category
+}
+
+// Min returns the lowest UV index value in the category
+func (c UVCategory) Min() int {
+	switch c {
+	case UVCategoryModerate:
+		return 3
+	case UVCategoryHigh:
+		return 6
+	case UVCategoryVeryHigh:
+		return 8
+	case UVCategoryExtreme:
+		return 11
+	}
+	return 0
+}
+
+// Advice returns the WHO sun protection advice for the category
+func (c UVCategory) Advice() string {
+	switch c {
+	case UVCategoryLow:
+		return "No protection needed. You can safely stay outside."
+	case UVCategoryModerate, UVCategoryHigh:
+		return "Protection needed. Seek shade during midday hours, cover up, and wear sunscreen and a hat."
+	case UVCategoryVeryHigh, UVCategoryExtreme:
+		return "Extra protection needed. Avoid being outside during midday hours. Seek shade; a shirt, sunscreen and hat are a must."
+	}
+	return ""
+}
+
+// Category returns the exposure category of the reading
+func (r UVIndexReading) Category() UVCategory {
+	return UVIndexCategory(r.Value)
+}
+
+// Peak returns the highest hourly reading of the item (the earliest, if tied), or nil if there are no readings
+func (item UVIndexReadingsResultItem) Peak() *UVIndexReading {
+	var peak *UVIndexReading
+	for i := range item.Index {
+		reading := item.Index[i]
+		if peak == nil || reading.Value > peak.Value ||
+			(reading.Value == peak.Value && reading.Timestamp < peak.Timestamp) {
+			peak = &reading
+		}
+	}
+	return peak
+}
+
+// HoursAtOrAbove returns the number of hourly readings of the item at or above the given category
+func (item UVIndexReadingsResultItem) HoursAtOrAbove(c UVCategory) int {
+	hours := 0
+	for _, reading := range item.Index {
+		if reading.Value >= c.Min() {
+			hours++
+		}
+	}
+	return hours
+}
@@ -0,0 +1,102 @@
+package datagovsg_test
+
+import (
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"testing"
+)
+
+func TestUVIndexCategory(t *testing.T) {
+	tests := []struct {
+		value    int
+		expected datagovsg.UVCategory
+	}{
+		{0, datagovsg.UVCategoryLow},
+		{2, datagovsg.UVCategoryLow},
+		{3, datagovsg.UVCategoryModerate},
+		{5, datagovsg.UVCategoryModerate},
+		{6, datagovsg.UVCategoryHigh},
+		{7, datagovsg.UVCategoryHigh},
+		{8, datagovsg.UVCategoryVeryHigh},
+		{10, datagovsg.UVCategoryVeryHigh},
+		{11, datagovsg.UVCategoryExtreme},
+		{15, datagovsg.UVCategoryExtreme},
+	}
+	for _, test := range tests {
+		if category := datagovsg.UVIndexCategory(test.value); category != test.expected {
+			t.Errorf("UVIndexCategory(%v): expected %v, got %v", test.value, test.expected, category)
+		}
+	}
+}
+
+var uvHours = []string{
+	"2016-12-01T11:00:00+08:00",
+	"2016-12-01T10:00:00+08:00",
+	"2016-12-01T09:00:00+08:00",
+	"2016-12-01T08:00:00+08:00",
+	"2016-12-01T07:00:00+08:00",
+}
+
+// uvItem returns an item with hourly readings of the given values, latest first as published
+func uvItem(values ...int) datagovsg.UVIndexReadingsResultItem {
+	item := datagovsg.UVIndexReadingsResultItem{}
+	for i, value := range values {
+		item.Index = append(item.Index, datagovsg.UVIndexReading{
+			Value:     value,
+			Timestamp: uvHours[i],
+		})
+	}
+	return item
+}
+
+func TestUVIndexReadingsResultItem_Peak(t *testing.T) {
+	tests := []struct {
+		item      datagovsg.UVIndexReadingsResultItem
+		value     int
+		timestamp string
+	}{
+		{uvItem(3, 8, 5), 8, "2016-12-01T10:00:00+08:00"},
+		{uvItem(2), 2, "2016-12-01T11:00:00+08:00"},
+		// ties go to the earliest reading
+		{uvItem(7, 7, 3, 7), 7, "2016-12-01T08:00:00+08:00"},
+		{uvItem(0, 0), 0, "2016-12-01T10:00:00+08:00"},
+	}
+	for _, test := range tests {
+		peak := test.item.Peak()
+		if peak == nil || peak.Value != test.value || peak.Timestamp != test.timestamp {
+			t.Errorf("%+v: expected peak %v at %v, got %+v", test.item.Index, test.value, test.timestamp, peak)
+		}
+	}
+
+	if peak := uvItem().Peak(); peak != nil {
+		t.Errorf("expected no peak without readings, got %+v", peak)
+	}
+}
+
+func TestUVIndexReadingsResultItem_HoursAtOrAbove(t *testing.T) {
+	item := uvItem(2, 3, 5, 6, 7)
+	tests := []struct {
+		category datagovsg.UVCategory
+		expected int
+	}{
+		{datagovsg.UVCategoryLow, 5},
+		{datagovsg.UVCategoryModerate, 4},
+		{datagovsg.UVCategoryHigh, 2},
+		{datagovsg.UVCategoryVeryHigh, 0},
+		{datagovsg.UVCategoryExtreme, 0},
+	}
+	for _, test := range tests {
+		if hours := item.HoursAtOrAbove(test.category); hours != test.expected {
+			t.Errorf("HoursAtOrAbove(%v): expected %v, got %v", test.category, test.expected, hours)
+		}
+	}
+
+	if hours := uvItem(7, 8, 10, 11).HoursAtOrAbove(datagovsg.UVCategoryVeryHigh); hours != 3 {
+		t.Errorf("expected 3 hours at or above VERY_HIGH, got %v", hours)
+	}
+	if hours := uvItem(10, 11).HoursAtOrAbove(datagovsg.UVCategoryExtreme); hours != 1 {
+		t.Errorf("expected 1 hour at or above EXTREME, got %v", hours)
+	}
+	if hours := uvItem().HoursAtOrAbove(datagovsg.UVCategoryLow); hours != 0 {
+		t.Errorf("expected no hours without readings, got %v", hours)
+	}
+}
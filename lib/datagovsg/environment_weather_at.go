@@ -0,0 +1,113 @@
+package datagovsg
+
+import (
+	"time"
+)
+
+// WeatherAtSources holds the upstream results that a WeatherAt is composed from
+type WeatherAtSources struct {
+	TwoHourWeatherForecast        *TwoHourWeatherForecastResult
+	TwentyFourHourWeatherForecast *TwentyFourHourWeatherForecastResult
+	PSI                           *PSIReadingsResult
+	PM25                          *PM25ReadingsResult
+	UVIndex                       *UVIndexReadingsResult
+}
+
+// NearestRegion returns the PSI/PM2.5 region whose label location is closest to the given location
+func (src WeatherAtSources) NearestRegion(loc Location) string {
+	areas := []Area{}
+	if src.PSI != nil {
+		areas = src.PSI.RegionMetadata
+	}
+	if len(areas) == 0 && src.PM25 != nil {
+		areas = src.PM25.RegionMetadata
+	}
+	return NearestArea(areas, loc).Name
+}
+
+// NewWeatherAt picks the readings and forecasts relevant to a location at a point in time.
+// If region is empty, the region with the nearest label location is used.
+func NewWeatherAt(loc Location, region string, at time.Time, src WeatherAtSources) WeatherAtGraphQL {
+	if region == "" {
+		region = src.NearestRegion(loc)
+	}
+	w := WeatherAtGraphQL{
+		Location: loc,
+		Region:   region,
+	}
+
+	if resp := src.TwoHourWeatherForecast; resp != nil && len(resp.Items) > 0 {
+		item := resp.Items[len(resp.Items)-1]
+		for _, i := range resp.Items {
+			if i.ValidPeriod.Contains(at) {
+				item = i
+				break
+			}
+		}
+		w.Area = NearestArea(resp.AreaMetadata, loc)
+		w.ValidPeriod = item.ValidPeriod
+		for _, f := range item.Forecasts {
+			if f.Area == w.Area.Name {
+				w.Forecast = f.Forecast
+				break
+			}
+		}
+	}
+
+	if resp := src.TwentyFourHourWeatherForecast; resp != nil && len(resp.Items) > 0 {
+		item := resp.Items[len(resp.Items)-1]
+		w.General = item.General
+		if len(item.Periods) > 0 {
+			period := item.Periods[0]
+			for _, p := range item.Periods {
+				if p.Time.Contains(at) {
+					period = p
+					break
+				}
+			}
+			w.RegionForecast = period.Regions.ByRegion(w.Region)
+			w.RegionForecastPeriod = period.Time
+		}
+	}
+
+	if resp := src.PSI; resp != nil && len(resp.Items) > 0 {
+		item := resp.Items[len(resp.Items)-1]
+		w.PSITwentyFourHourly = item.Readings.PSITwentyFourHourly.ByRegion(w.Region)
+		w.PSIThreeHourly = item.Readings.PSIThreeHourly.ByRegion(w.Region)
+	}
+
+	if resp := src.PM25; resp != nil && len(resp.Items) > 0 {
+		item := resp.Items[len(resp.Items)-1]
+		w.PM25OneHourly = item.Readings.PM25OneHourly.ByRegion(w.Region)
+	}
+
+	if resp := src.UVIndex; resp != nil && len(resp.Items) > 0 {
+		// readings are listed latest first; take the latest one that is not after the requested time
+		item := resp.Items[len(resp.Items)-1]
+		for _, reading := range item.Index {
+			t, err := ParseTimestamp(reading.Timestamp)
+			if err != nil || t.After(at) {
+				continue
+			}
+			w.UVIndex = reading
+			break
+		}
+	}
+
+	return w
+}
+
+type WeatherAtGraphQL struct {
+	Location             Location                             `json:"location,omitempty"`
+	Region               string                               `json:"region,omitempty"`
+	Area                 Area                                 `json:"area,omitempty"`
+	Forecast             string                               `json:"forecast,omitempty"`
+	ValidPeriod          DatetimeRange                        `json:"valid_period,omitempty"`
+	RegionForecast       string                               `json:"region_forecast,omitempty"`
+	RegionForecastPeriod DatetimeRange                        `json:"region_forecast_period,omitempty"`
+	General              GeneralTwentyFourHourWeatherForecast `json:"general,omitempty"`
+	PSITwentyFourHourly  float32                              `json:"psi_twenty_four_hourly,omitempty"`
+	PSIThreeHourly       float32                              `json:"psi_three_hourly,omitempty"`
+	PM25OneHourly        int                                  `json:"pm25_one_hourly,omitempty"`
+	UVIndex              UVIndexReading                       `json:"uv_index,omitempty"`
+}
@@ -0,0 +1,156 @@
+package datagovsg_test
+
+import (
+	"encoding/json"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+// loadSample decodes a sample response from ./sample
+func loadSample(t *testing.T, name string, target interface{}) {
+	data, err := ioutil.ReadFile("./sample/" + name + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func weatherAtSources(t *testing.T) datagovsg.WeatherAtSources {
+	src := datagovsg.WeatherAtSources{
+		TwoHourWeatherForecast:        &datagovsg.TwoHourWeatherForecastResult{},
+		TwentyFourHourWeatherForecast: &datagovsg.TwentyFourHourWeatherForecastResult{},
+		PSI:                           &datagovsg.PSIReadingsResult{},
+		PM25:                          &datagovsg.PM25ReadingsResult{},
+		UVIndex:                       &datagovsg.UVIndexReadingsResult{},
+	}
+	loadSample(t, "environment_two_hour_weather_forecast", src.TwoHourWeatherForecast)
+	loadSample(t, "environment_twenty_four_hour_weather_forecast", src.TwentyFourHourWeatherForecast)
+	loadSample(t, "environment_psi", src.PSI)
+	loadSample(t, "environment_pm25", src.PM25)
+	loadSample(t, "environment_uv_index", src.UVIndex)
+	return src
+}
+
+func sgt(s string) time.Time {
+	t, err := datagovsg.ParseTimestamp(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestNewWeatherAt(t *testing.T) {
+	src := weatherAtSources(t)
+	tests := []struct {
+		name     string
+		loc      datagovsg.Location
+		region   string
+		at       time.Time
+		expected datagovsg.WeatherAtGraphQL
+	}{
+		{
+			// the region of the point's boundary is used, even where another region's label is nearer
+			name:   "inside a boundary",
+			loc:    datagovsg.Location{Latitude: 1.30, Longitude: 103.86},
+			region: "central",
+			at:     sgt("2016-05-09T13:00:00+08:00"),
+			expected: datagovsg.WeatherAtGraphQL{
+				Region:               "central",
+				Area:                 datagovsg.Area{Name: "Kallang"},
+				RegionForecast:       "Thundery Showers",
+				RegionForecastPeriod: datagovsg.DatetimeRange{Start: "2016-05-09T12:00:00+08:00", End: "2016-05-09T18:00:00+08:00"},
+				PSITwentyFourHourly:  29,
+				PSIThreeHourly:       33,
+				PM25OneHourly:        6,
+			},
+		},
+		{
+			name: "outside every boundary",
+			loc:  datagovsg.Location{Latitude: 1.30, Longitude: 103.86},
+			at:   sgt("2016-05-09T13:00:00+08:00"),
+			expected: datagovsg.WeatherAtGraphQL{
+				Region:               "south",
+				Area:                 datagovsg.Area{Name: "Kallang"},
+				RegionForecast:       "Thundery Showers",
+				RegionForecastPeriod: datagovsg.DatetimeRange{Start: "2016-05-09T12:00:00+08:00", End: "2016-05-09T18:00:00+08:00"},
+				PSITwentyFourHourly:  38,
+				PSIThreeHourly:       33,
+				PM25OneHourly:        6,
+			},
+		},
+		{
+			// the later period starts at its boundary with the earlier one
+			name:   "between forecast periods",
+			loc:    datagovsg.Location{Latitude: 1.35, Longitude: 103.99},
+			region: "east",
+			at:     sgt("2016-05-09T18:00:00+08:00"),
+			expected: datagovsg.WeatherAtGraphQL{
+				Region:               "east",
+				Area:                 datagovsg.Area{Name: "Changi"},
+				RegionForecast:       "Partly Cloudy (Night)",
+				RegionForecastPeriod: datagovsg.DatetimeRange{Start: "2016-05-09T18:00:00+08:00", End: "2016-05-10T06:00:00+08:00"},
+				PSITwentyFourHourly:  34,
+				PSIThreeHourly:       56,
+				PM25OneHourly:        13,
+			},
+		},
+		{
+			name:   "latest UV reading at the time",
+			loc:    datagovsg.Location{Latitude: 1.35, Longitude: 103.99},
+			region: "east",
+			at:     sgt("2016-05-11T10:30:00+08:00"),
+			expected: datagovsg.WeatherAtGraphQL{
+				Region:               "east",
+				Area:                 datagovsg.Area{Name: "Changi"},
+				RegionForecast:       "Thundery Showers",
+				RegionForecastPeriod: datagovsg.DatetimeRange{Start: "2016-05-09T12:00:00+08:00", End: "2016-05-09T18:00:00+08:00"},
+				PSITwentyFourHourly:  34,
+				PSIThreeHourly:       56,
+				PM25OneHourly:        13,
+				UVIndex:              datagovsg.UVIndexReading{Value: 3, Timestamp: "2016-05-11T10:00:00+08:00"},
+			},
+		},
+	}
+	for _, test := range tests {
+		w := datagovsg.NewWeatherAt(test.loc, test.region, test.at, src)
+		expected := test.expected
+		if w.Region != expected.Region || w.Area.Name != expected.Area.Name ||
+			w.RegionForecast != expected.RegionForecast || w.RegionForecastPeriod != expected.RegionForecastPeriod ||
+			w.PSITwentyFourHourly != expected.PSITwentyFourHourly || w.PSIThreeHourly != expected.PSIThreeHourly ||
+			w.PM25OneHourly != expected.PM25OneHourly || w.UVIndex != expected.UVIndex {
+			t.Errorf("%v: expected %+v, got %+v", test.name, expected, w)
+		}
+		if w.Location != test.loc || w.General.Forecast != "Thundery Showers" {
+			t.Errorf("%v: unexpected location or general forecast in %+v", test.name, w)
+		}
+		// the sample has a single two-hour forecast, which is used outside its valid period too
+		if w.ValidPeriod.Start != "2016-05-07T23:30:00+08:00" || w.Forecast != "Partly Cloudy (Night)" {
+			t.Errorf("%v: unexpected two-hour forecast in %+v", test.name, w)
+		}
+	}
+}
+
+func TestNewWeatherAt_MissingUVIndex(t *testing.T) {
+	loc := datagovsg.Location{Latitude: 1.35, Longitude: 103.99}
+
+	// before the first reading of the day
+	src := weatherAtSources(t)
+	if w := datagovsg.NewWeatherAt(loc, "", sgt("2016-05-11T06:00:00+08:00"), src); w.UVIndex != (datagovsg.UVIndexReading{}) {
+		t.Errorf("expected no UV reading before the first one, got %+v", w.UVIndex)
+	}
+
+	// no UV index response at all, or one without items
+	src.UVIndex = nil
+	w := datagovsg.NewWeatherAt(loc, "", sgt("2016-05-11T12:00:00+08:00"), src)
+	if w.UVIndex != (datagovsg.UVIndexReading{}) || w.Region != "east" || w.PSITwentyFourHourly != 34 {
+		t.Errorf("expected everything but the UV reading without a UV response, got %+v", w)
+	}
+	src.UVIndex = &datagovsg.UVIndexReadingsResult{}
+	if w := datagovsg.NewWeatherAt(loc, "", sgt("2016-05-11T12:00:00+08:00"), src); w.UVIndex != (datagovsg.UVIndexReading{}) {
+		t.Errorf("expected no UV reading without items, got %+v", w.UVIndex)
+	}
+}
@@ -0,0 +1,94 @@
+package datagovsg
+
+import (
+	"net/url"
+	"reflect"
+	"time"
+)
+
+// DatasetTTLs is roughly how often each data.gov.sg endpoint publishes new data, keyed by URL path
+var DatasetTTLs = map[string]time.Duration{
+	"/v1/transport/taxi-availability":          time.Minute,
+	"/v1/transport/traffic-images":             20 * time.Second,
+	"/v1/environment/2-hour-weather-forecast":  30 * time.Minute,
+	"/v1/environment/24-hour-weather-forecast": 30 * time.Minute,
+	"/v1/environment/4-day-weather-forecast":   30 * time.Minute,
+	"/v1/environment/psi":                      time.Hour,
+	"/v1/environment/pm25":                     time.Hour,
+	"/v1/environment/uv-index":                 time.Hour,
+}
+
+// DatasetTTL returns how long data fetched from the URL stays fresh, or 0 if the endpoint is unknown
+func DatasetTTL(rawurl string) time.Duration {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return 0
+	}
+	return DatasetTTLs[u.Path]
+}
+
+// dataTimestamp returns the latest timestamp or update_timestamp of a response's items, or fetchedAt if it has none
+func dataTimestamp(body interface{}, fetchedAt time.Time) time.Time {
+	v := reflect.Indirect(reflect.ValueOf(body))
+	if v.Kind() != reflect.Struct {
+		return fetchedAt
+	}
+	items := v.FieldByName("Items")
+	if !items.IsValid() || items.Kind() != reflect.Slice {
+		return fetchedAt
+	}
+	var latest time.Time
+	for i := 0; i < items.Len(); i++ {
+		item := reflect.Indirect(items.Index(i))
+		if item.Kind() != reflect.Struct {
+			continue
+		}
+		for _, name := range []string{"Timestamp", "UpdateTimestamp"} {
+			field := item.FieldByName(name)
+			if !field.IsValid() || field.Kind() != reflect.String {
+				continue
+			}
+			if t, err := ParseTimestamp(field.String()); err == nil && t.After(latest) {
+				latest = t
+			}
+		}
+	}
+	if latest.IsZero() {
+		return fetchedAt
+	}
+	return latest
+}
+
+// Touched returns the URLs successfully fetched through the Client so far
+func (c *Client) Touched() []string {
+	c.trackLock.RLock()
+	defer c.trackLock.RUnlock()
+	urls := []string{}
+	for url := range c.dataAt {
+		urls = append(urls, url)
+	}
+	return urls
+}
+
+// Freshness returns the shortest time left before any data fetched by the Client goes stale: the TTL of its endpoint
+// after the latest timestamp in its response, so historical data is already stale. It is 0 if a fetch failed, if
+// data.gov.sg reported an endpoint as unhealthy, or if an endpoint is not in DatasetTTLs, and ok is false if nothing
+// was fetched.
+func (c *Client) Freshness() (freshness time.Duration, ok bool) {
+	c.trackLock.RLock()
+	defer c.trackLock.RUnlock()
+	if c.failed {
+		return 0, true
+	}
+	for url, dataAt := range c.dataAt {
+		remaining := DatasetTTL(url) - time.Since(dataAt)
+		if remaining < 0 {
+			remaining = 0
+		}
+		if !ok || remaining < freshness {
+			freshness = remaining
+		}
+		ok = true
+	}
+	return freshness, ok
+}
@@ -0,0 +1,103 @@
+package datagovsg_test
+
+import (
+	"fmt"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDatasetTTL(t *testing.T) {
+	for url, expected := range map[string]time.Duration{
+		"https://api.data.gov.sg/v1/transport/taxi-availability":                         time.Minute,
+		"https://api.data.gov.sg/v1/environment/psi?date_time=2016-12-01T10%3A00%3A00":   time.Hour,
+		"https://api.data.gov.sg/v1/environment/2-hour-weather-forecast?date=2016-12-01": 30 * time.Minute,
+		"https://api.data.gov.sg/v1/environment/unknown":                                 0,
+	} {
+		if ttl := datagovsg.DatasetTTL(url); ttl != expected {
+			t.Errorf("DatasetTTL(%v) = %v, expected %v", url, ttl, expected)
+		}
+	}
+}
+
+func TestFreshness_NothingFetched(t *testing.T) {
+	if _, ok := datagovsg.NewClient("").Freshness(); ok {
+		t.Fatal("expected ok to be false before anything is fetched")
+	}
+}
+
+// fetchPSI fetches a PSI response from a server replying with the given status and body, and returns the client's
+// freshness and the fetch's error
+func fetchPSI(t *testing.T, status int, body string) (time.Duration, error) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	c := datagovsg.NewClient("")
+	res := <-c.Get(server.URL+"/v1/environment/psi", &datagovsg.PSIReadingsResult{})
+	freshness, ok := c.Freshness()
+	if !ok {
+		t.Fatal("expected ok once something was fetched")
+	}
+	return freshness, res.Err
+}
+
+func psiBody(status string, timestamp time.Time) string {
+	return fmt.Sprintf(`{"api_info": {"status": %q}, "items": [{"timestamp": %q, "update_timestamp": %q}]}`,
+		status, timestamp.Add(-5*time.Minute).Format(time.RFC3339), timestamp.Format(time.RFC3339))
+}
+
+func TestFreshness(t *testing.T) {
+	// fresh for the hour after the latest update, however recently it was fetched
+	freshness, err := fetchPSI(t, http.StatusOK, psiBody("healthy", time.Now().Add(-20*time.Minute)))
+	if err != nil || freshness > 40*time.Minute || freshness < 39*time.Minute {
+		t.Errorf("expected about 40m left, got %v, %v", freshness, err)
+	}
+
+	// historical data
+	if freshness, err := fetchPSI(t, http.StatusOK, psiBody("healthy", time.Now().Add(-2*time.Hour))); err != nil || freshness != 0 {
+		t.Errorf("expected old data to be stale, got %v, %v", freshness, err)
+	}
+
+	if freshness, err := fetchPSI(t, http.StatusOK, psiBody("unhealthy", time.Now())); err != nil || freshness != 0 {
+		t.Errorf("expected an unhealthy response not to be cached, got %v, %v", freshness, err)
+	}
+
+	if freshness, err := fetchPSI(t, http.StatusInternalServerError, psiBody("healthy", time.Now())); err == nil || freshness != 0 {
+		t.Errorf("expected an error response to fail, got %v, %v", freshness, err)
+	}
+}
+
+func TestClient_Operation(t *testing.T) {
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		fmt.Fprint(w, psiBody("healthy", time.Now()))
+	}))
+	defer server.Close()
+
+	batch := datagovsg.NewClient("")
+	a, b := batch.Operation(), batch.Operation()
+	psi, uv := server.URL+"/v1/environment/psi", server.URL+"/v1/environment/uv-index"
+	<-a.Get(psi, &datagovsg.PSIReadingsResult{})
+	<-b.Get(psi, &datagovsg.PSIReadingsResult{})
+	<-b.Get(uv, &datagovsg.UVIndexReadingsResult{})
+	if fetches != 2 {
+		t.Fatalf("expected operations to share fetches, got %v fetches", fetches)
+	}
+
+	if touched := a.Touched(); len(touched) != 1 || touched[0] != psi {
+		t.Errorf("expected the first operation to have touched only %v, got %v", psi, touched)
+	}
+	if touched := b.Touched(); len(touched) != 2 {
+		t.Errorf("expected the second operation to have touched 2 URLs, got %v", touched)
+	}
+	if _, ok := batch.Freshness(); ok {
+		t.Error("expected nothing to be tracked by the batch client itself")
+	}
+}
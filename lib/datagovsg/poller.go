@@ -0,0 +1,181 @@
+package datagovsg
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Poller periodically fetches data.gov.sg endpoints in the background and hands the results to a handler
+type Poller struct {
+	APIKey string
+
+	// HTTPClient makes the polls, with a timeout so that a hung request does not hold up the next poll
+	HTTPClient *http.Client
+
+	watches []*watch
+	stop    chan struct{}
+	wg      sync.WaitGroup
+
+	statusLock sync.RWMutex
+}
+
+type watch struct {
+	url      string
+	interval time.Duration
+	handlers []watchHandler
+	status   PollStatus
+}
+
+type watchHandler struct {
+	newTarget func() interface{}
+	handler   func(ClientResult)
+}
+
+// PollStatus is the outcome of the latest poll of a URL
+type PollStatus struct {
+	URL         string    `json:"url"`
+	LastPoll    time.Time `json:"last_poll"`
+	LastSuccess time.Time `json:"last_success"`
+	Error       string    `json:"error,omitempty"`
+
+	// APIStatus is the api_info.status of the latest response, if it has one
+	APIStatus string `json:"api_status,omitempty"`
+}
+
+// Polled returns true once the URL has been polled
+func (s PollStatus) Polled() bool {
+	return !s.LastPoll.IsZero()
+}
+
+// OK returns true if the latest poll succeeded and data.gov.sg did not report the endpoint as unhealthy
+func (s PollStatus) OK() bool {
+	return s.Polled() && s.Error == "" && (s.APIStatus == "" || s.APIStatus == "healthy")
+}
+
+// NewPoller returns a new Poller
+func NewPoller(apiKey string) *Poller {
+	return &Poller{
+		APIKey:     apiKey,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Watch registers a URL to be fetched every interval. newTarget returns a fresh value to decode each response into.
+// A URL watched more than once is fetched once per poll, at the shortest interval, and each handler gets its own
+// decoding of the response. Watch must be called before Start.
+func (p *Poller) Watch(url string, interval time.Duration, newTarget func() interface{}, handler func(ClientResult)) {
+	h := watchHandler{newTarget: newTarget, handler: handler}
+	for _, w := range p.watches {
+		if w.url == url {
+			w.handlers = append(w.handlers, h)
+			if interval < w.interval {
+				w.interval = interval
+			}
+			return
+		}
+	}
+	p.watches = append(p.watches, &watch{
+		url:      url,
+		interval: interval,
+		handlers: []watchHandler{h},
+		status:   PollStatus{URL: url},
+	})
+}
+
+// Start polls every watched URL straight away, and then on its interval until Stop is called
+func (p *Poller) Start() {
+	p.stop = make(chan struct{})
+	for _, w := range p.watches {
+		p.wg.Add(1)
+		go func(w *watch) {
+			defer p.wg.Done()
+			ticker := time.NewTicker(w.interval)
+			defer ticker.Stop()
+			for {
+				client := NewClient(p.APIKey)
+				client.HTTPClient = p.HTTPClient
+				raw := json.RawMessage{}
+				res := <-client.Get(w.url, &raw)
+				results := []ClientResult{}
+				for _, h := range w.handlers {
+					results = append(results, decode(res, raw, h.newTarget))
+				}
+				p.record(w, results)
+				for i, h := range w.handlers {
+					h.handler(results[i])
+				}
+				select {
+				case <-p.stop:
+					return
+				case <-ticker.C:
+				}
+			}
+		}(w)
+	}
+}
+
+// decode decodes a polled response into a fresh target for a handler
+func decode(res ClientResult, raw json.RawMessage, newTarget func() interface{}) ClientResult {
+	if res.Err != nil {
+		return res
+	}
+	target := newTarget()
+	err := json.Unmarshal(raw, target)
+	return ClientResult{Body: target, Err: err}
+}
+
+// record updates the status of a watch with the results of a poll, decoded for each of its handlers
+func (p *Poller) record(w *watch, results []ClientResult) {
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+	w.status.LastPoll = time.Now()
+	w.status.Error = ""
+	w.status.APIStatus = ""
+	for _, res := range results {
+		if res.Err != nil {
+			w.status.Error = res.Err.Error()
+			return
+		}
+		if w.status.APIStatus == "" {
+			w.status.APIStatus = apiStatus(res.Body)
+		}
+	}
+	w.status.LastSuccess = w.status.LastPoll
+}
+
+// apiStatus returns the api_info.status of a response, or "" if it has no APIInfo field
+func apiStatus(body interface{}) string {
+	v := reflect.Indirect(reflect.ValueOf(body))
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	field := v.FieldByName("APIInfo")
+	if !field.IsValid() {
+		return ""
+	}
+	info, _ := field.Interface().(APIInfo)
+	return info.Status
+}
+
+// Statuses returns the status of every watched URL, once each, in the order they were first watched
+func (p *Poller) Statuses() []PollStatus {
+	p.statusLock.RLock()
+	defer p.statusLock.RUnlock()
+	statuses := []PollStatus{}
+	for _, w := range p.watches {
+		statuses = append(statuses, w.status)
+	}
+	return statuses
+}
+
+// Stop stops polling and waits for in-flight handlers to return
+func (p *Poller) Stop() {
+	if p.stop == nil {
+		return
+	}
+	close(p.stop)
+	p.wg.Wait()
+}
@@ -0,0 +1,50 @@
+package datagovsg_test
+
+import (
+	"fmt"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPoller_SharedWatch(t *testing.T) {
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		fmt.Fprint(w, `{"api_info": {"status": "healthy"}, "items": [{"timestamp": "2016-12-01T10:00:00+08:00"}]}`)
+	}))
+	defer server.Close()
+
+	p := datagovsg.NewPoller("")
+	url := server.URL + "/v1/transport/traffic-images"
+	images := make(chan *datagovsg.TrafficImagesResult, 1)
+	p.Watch(url, time.Hour, func() interface{} { return &datagovsg.TrafficImagesResult{} }, func(res datagovsg.ClientResult) {
+		body, _ := res.Body.(*datagovsg.TrafficImagesResult)
+		images <- body
+	})
+	timestamps := make(chan *map[string]interface{}, 1)
+	p.Watch(url, time.Minute, func() interface{} { return &map[string]interface{}{} }, func(res datagovsg.ClientResult) {
+		body, _ := res.Body.(*map[string]interface{})
+		timestamps <- body
+	})
+	p.Start()
+	image, timestamp := <-images, <-timestamps
+	p.Stop()
+
+	if fetches != 1 {
+		t.Errorf("expected one fetch for both watches, got %v", fetches)
+	}
+	if image == nil || len(image.Items) != 1 || image.Items[0].Timestamp != "2016-12-01T10:00:00+08:00" {
+		t.Errorf("expected the first handler to get its own decoding, got %+v", image)
+	}
+	if timestamp == nil || (*timestamp)["items"] == nil {
+		t.Errorf("expected the second handler to get its own decoding, got %+v", timestamp)
+	}
+	statuses := p.Statuses()
+	if len(statuses) != 1 || statuses[0].URL != url || !statuses[0].OK() || statuses[0].APIStatus != "healthy" {
+		t.Errorf("expected one healthy status for the shared URL, got %+v", statuses)
+	}
+}
@@ -0,0 +1,142 @@
+package datagovsg
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// Neighbour is a location returned from a nearest-neighbour search
+type Neighbour struct {
+	Location Location `json:"location"`
+	Distance float64  `json:"distance_m"`
+	Bearing  float64  `json:"bearing"`
+}
+
+// PointIndex is a k-d tree over a fixed set of locations, for nearest-neighbour queries.
+// It is read-only once built, and safe to query from multiple go-routines.
+type PointIndex struct {
+	locations []Location
+	// projected [x, y] coordinates, so that euclidean distance approximates ground distance
+	points [][2]float64
+	nodes  []int
+	scale  float64
+}
+
+// NewPointIndex builds a PointIndex over the given locations
+func NewPointIndex(locations []Location) *PointIndex {
+	idx := &PointIndex{
+		locations: locations,
+		points:    make([][2]float64, len(locations)),
+		nodes:     make([]int, len(locations)),
+		scale:     1,
+	}
+	if len(locations) > 0 {
+		sum := 0.0
+		for _, loc := range locations {
+			sum += loc.Latitude
+		}
+		idx.scale = math.Cos(sum / float64(len(locations)) * math.Pi / 180)
+	}
+	for i, loc := range locations {
+		idx.points[i] = idx.project(loc)
+		idx.nodes[i] = i
+	}
+	idx.build(0, len(idx.nodes), 0)
+	return idx
+}
+
+func (idx *PointIndex) project(loc Location) [2]float64 {
+	return [2]float64{loc.Longitude * idx.scale, loc.Latitude}
+}
+
+// build arranges nodes[lo:hi] so that its median (by the axis for this depth) is in the middle,
+// with the left and right halves built recursively
+func (idx *PointIndex) build(lo, hi, depth int) {
+	if hi-lo <= 1 {
+		return
+	}
+	axis := depth % 2
+	nodes := idx.nodes[lo:hi]
+	sort.Slice(nodes, func(i, j int) bool {
+		return idx.points[nodes[i]][axis] < idx.points[nodes[j]][axis]
+	})
+	mid := lo + (hi-lo)/2
+	idx.build(lo, mid, depth+1)
+	idx.build(mid+1, hi, depth+1)
+}
+
+// Len returns the number of locations in the index
+func (idx *PointIndex) Len() int {
+	return len(idx.locations)
+}
+
+// Nearest returns up to k locations closest to loc, sorted by distance
+func (idx *PointIndex) Nearest(loc Location, k int) []Neighbour {
+	if k <= 0 || len(idx.nodes) == 0 {
+		return []Neighbour{}
+	}
+	h := &candidateHeap{}
+	idx.search(idx.project(loc), k, 0, len(idx.nodes), 0, h)
+
+	neighbours := make([]Neighbour, 0, h.Len())
+	for _, c := range *h {
+		other := idx.locations[c.index]
+		neighbours = append(neighbours, Neighbour{
+			Location: other,
+			Distance: loc.DistanceTo(other),
+			Bearing:  loc.BearingTo(other),
+		})
+	}
+	sort.Slice(neighbours, func(i, j int) bool {
+		return neighbours[i].Distance < neighbours[j].Distance
+	})
+	return neighbours
+}
+
+func (idx *PointIndex) search(target [2]float64, k, lo, hi, depth int, h *candidateHeap) {
+	if lo >= hi {
+		return
+	}
+	axis := depth % 2
+	mid := lo + (hi-lo)/2
+	node := idx.nodes[mid]
+	point := idx.points[node]
+
+	dx, dy := point[0]-target[0], point[1]-target[1]
+	if h.Len() < k {
+		heap.Push(h, candidate{node, dx*dx + dy*dy})
+	} else if d := dx*dx + dy*dy; d < (*h)[0].distance {
+		(*h)[0] = candidate{node, d}
+		heap.Fix(h, 0)
+	}
+
+	diff := target[axis] - point[axis]
+	nearLo, nearHi, farLo, farHi := lo, mid, mid+1, hi
+	if diff > 0 {
+		nearLo, nearHi, farLo, farHi = mid+1, hi, lo, mid
+	}
+	idx.search(target, k, nearLo, nearHi, depth+1, h)
+	if h.Len() < k || diff*diff < (*h)[0].distance {
+		idx.search(target, k, farLo, farHi, depth+1, h)
+	}
+}
+
+type candidate struct {
+	index    int
+	distance float64
+}
+
+// candidateHeap is a max-heap on distance, holding the best k candidates found so far
+type candidateHeap []candidate
+
+func (h candidateHeap) Len() int            { return len(h) }
+func (h candidateHeap) Less(i, j int) bool  { return h[i].distance > h[j].distance }
+func (h candidateHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *candidateHeap) Push(x interface{}) { *h = append(*h, x.(candidate)) }
+func (h *candidateHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
@@ -0,0 +1,60 @@
+package datagovsg_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+)
+
+func TestPointIndex_Nearest(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	locations := []datagovsg.Location{}
+	for i := 0; i < 2000; i++ {
+		locations = append(locations, datagovsg.Location{
+			Longitude: 103.6 + r.Float64()*0.4,
+			Latitude:  1.22 + r.Float64()*0.25,
+		})
+	}
+	idx := datagovsg.NewPointIndex(locations)
+
+	for i := 0; i < 20; i++ {
+		target := datagovsg.Location{
+			Longitude: 103.6 + r.Float64()*0.4,
+			Latitude:  1.22 + r.Float64()*0.25,
+		}
+		expected := append([]datagovsg.Location{}, locations...)
+		sort.Slice(expected, func(i, j int) bool {
+			return target.DistanceTo(expected[i]) < target.DistanceTo(expected[j])
+		})
+
+		neighbours := idx.Nearest(target, 5)
+		if len(neighbours) != 5 {
+			t.Fatalf("expected 5 neighbours, got %v", len(neighbours))
+		}
+		for j, n := range neighbours {
+			if n.Location != expected[j] {
+				t.Errorf("target %v, neighbour %v: expected %v, got %v", target, j, expected[j], n.Location)
+			}
+		}
+	}
+}
+
+func TestLocation_BearingTo(t *testing.T) {
+	from := datagovsg.Location{Longitude: 103.8, Latitude: 1.3}
+	tests := []struct {
+		to       datagovsg.Location
+		expected float64
+	}{
+		{datagovsg.Location{Longitude: 103.8, Latitude: 1.4}, 0},
+		{datagovsg.Location{Longitude: 103.9, Latitude: 1.3}, 90},
+		{datagovsg.Location{Longitude: 103.8, Latitude: 1.2}, 180},
+		{datagovsg.Location{Longitude: 103.7, Latitude: 1.3}, 270},
+	}
+	for _, test := range tests {
+		if bearing := from.BearingTo(test.to); bearing < test.expected-0.01 || bearing > test.expected+0.01 {
+			t.Errorf("bearing to %v: expected %v, got %v", test.to, test.expected, bearing)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package datagovsg
 
 import (
 	"encoding/json"
+	"sync"
 )
 
 type TaxiAvailabilityOptions struct {
@@ -13,9 +14,16 @@ type TaxiAvailabilityResultProperties struct {
 	TaxiCount int     `json:"taxi_count,omitempty"`
 	APIInfo   APIInfo `json:"api_info,omitempty"`
 }
+
+// TaxiAvailabilityGeometry is the MultiPoint geometry of available taxis, as [longitude, latitude] positions
+type TaxiAvailabilityGeometry struct {
+	Type        string      `json:"type,omitempty"`
+	Coordinates [][]float64 `json:"coordinates"`
+}
+
 type TaxiAvailabilityResultItem struct {
 	Type       string                           `json:"type,omitempty"`
-	Geometry   interface{}                      `json:"geometry,omitempty"`
+	Geometry   TaxiAvailabilityGeometry         `json:"geometry,omitempty"`
 	Properties TaxiAvailabilityResultProperties `json:"properties,omitempty"`
 }
 
@@ -24,6 +32,131 @@ type TaxiAvailabilityResult struct {
 	Type     string                       `json:"type,omitempty"`
 	CRS      interface{}                  `json:"crs,omitempty"`
 	Features []TaxiAvailabilityResultItem `json:"features,omitempty"`
+
+	// filtered is set on copies returned by Filter, which are not a full upstream snapshot
+	filtered bool
+}
+
+// Timestamp returns the timestamp of the snapshot
+func (resp *TaxiAvailabilityResult) Timestamp() string {
+	if resp == nil || len(resp.Features) == 0 {
+		return ""
+	}
+	return resp.Features[0].Properties.Timestamp
+}
+
+// Filter returns a copy of the result keeping only the taxis for which keep returns true.
+// The taxi count is updated to match. The original result is left untouched, since it may be shared
+// with other fields through the Client.
+func (resp *TaxiAvailabilityResult) Filter(keep func(longitude, latitude float64) bool) *TaxiAvailabilityResult {
+	if resp == nil {
+		return nil
+	}
+	filtered := *resp
+	filtered.filtered = true
+	filtered.Features = make([]TaxiAvailabilityResultItem, len(resp.Features))
+	for i, feature := range resp.Features {
+		coordinates := [][]float64{}
+		for _, position := range feature.Geometry.Coordinates {
+			if len(position) < 2 {
+				continue
+			}
+			if keep(position[0], position[1]) {
+				coordinates = append(coordinates, position)
+			}
+		}
+		feature.Geometry.Coordinates = coordinates
+		feature.Properties.TaxiCount = len(coordinates)
+		filtered.Features[i] = feature
+	}
+	return &filtered
+}
+
+// Coordinates returns the [longitude, latitude] positions of all available taxis
+func (resp *TaxiAvailabilityResult) Coordinates() [][]float64 {
+	coordinates := [][]float64{}
+	if resp == nil {
+		return coordinates
+	}
+	for _, feature := range resp.Features {
+		coordinates = append(coordinates, feature.Geometry.Coordinates...)
+	}
+	return coordinates
+}
+
+// maxTaxiIndexes is the number of snapshot indexes kept around, to serve both the latest snapshot
+// and a few historic (date_time) ones without rebuilding
+const maxTaxiIndexes = 4
+
+type taxiIndexEntry struct {
+	once  sync.Once
+	index *PointIndex
+}
+
+// taxiIndexes caches spatial indexes by snapshot timestamp, shared by all requests
+var taxiIndexes = struct {
+	sync.Mutex
+	entries map[string]*taxiIndexEntry
+	order   []string
+}{
+	entries: map[string]*taxiIndexEntry{},
+}
+
+// Index returns a spatial index of the taxi locations.
+// Indexes of upstream snapshots are built once per snapshot timestamp and shared across requests;
+// filtered results get an index of their own.
+func (resp *TaxiAvailabilityResult) Index() *PointIndex {
+	timestamp := resp.Timestamp()
+	if resp.filtered || timestamp == "" {
+		return NewPointIndex(resp.Locations())
+	}
+
+	taxiIndexes.Lock()
+	entry, ok := taxiIndexes.entries[timestamp]
+	if !ok {
+		entry = &taxiIndexEntry{}
+		taxiIndexes.entries[timestamp] = entry
+		taxiIndexes.order = append(taxiIndexes.order, timestamp)
+		if len(taxiIndexes.order) > maxTaxiIndexes {
+			delete(taxiIndexes.entries, taxiIndexes.order[0])
+			taxiIndexes.order = taxiIndexes.order[1:]
+		}
+	}
+	taxiIndexes.Unlock()
+
+	entry.once.Do(func() {
+		entry.index = NewPointIndex(resp.Locations())
+	})
+	return entry.index
+}
+
+// Locations returns the locations of all available taxis
+func (resp *TaxiAvailabilityResult) Locations() []Location {
+	locations := []Location{}
+	for _, position := range resp.Coordinates() {
+		if len(position) < 2 {
+			continue
+		}
+		locations = append(locations, Location{
+			Longitude: position[0],
+			Latitude:  position[1],
+		})
+	}
+	return locations
+}
+
+// WithinRadius returns the taxis within radius (in metres) of center
+func (resp *TaxiAvailabilityResult) WithinRadius(center Location, radius float64) *TaxiAvailabilityResult {
+	return resp.Filter(func(longitude, latitude float64) bool {
+		return center.DistanceTo(Location{Longitude: longitude, Latitude: latitude}) <= radius
+	})
+}
+
+// WithinBoundingBox returns the taxis within the [minLongitude, minLatitude, maxLongitude, maxLatitude] bounding box
+func (resp *TaxiAvailabilityResult) WithinBoundingBox(bbox [4]float64) *TaxiAvailabilityResult {
+	return resp.Filter(func(longitude, latitude float64) bool {
+		return longitude >= bbox[0] && latitude >= bbox[1] && longitude <= bbox[2] && latitude <= bbox[3]
+	})
 }
 
 func (resp *TaxiAvailabilityResult) ToGraphQL() interface{} {
@@ -47,6 +180,7 @@ func (resp *TaxiAvailabilityResult) ToGraphQL() interface{} {
 		TaxiCount: feature.Properties.TaxiCount,
 		APIInfo:   feature.Properties.APIInfo,
 		Result:    geoJSONMap,
+		Source:    resp,
 	}
 }
 
@@ -55,4 +189,7 @@ type TaxiAvailabilityResultGraphQL struct {
 	TaxiCount int                    `json:"taxi_count,omitempty"`
 	APIInfo   APIInfo                `json:"api_info,omitempty"`
 	Result    map[string]interface{} `json:"result,omitempty"`
+
+	// Source is the (possibly filtered) result this was converted from, for fields computed from the taxi positions
+	Source *TaxiAvailabilityResult `json:"-"`
 }
@@ -0,0 +1,96 @@
+package datagovsg_test
+
+import (
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"reflect"
+	"testing"
+)
+
+func TestTaxiAvailabilityResult_Filter(t *testing.T) {
+	resp := &datagovsg.TaxiAvailabilityResult{}
+	loadSample(t, "transport_taxi_availability", resp)
+	original := resp.Coordinates()
+	if len(original) != 7290 || resp.Features[0].Properties.TaxiCount != 7290 {
+		t.Fatalf("unexpected sample with %v taxis", len(original))
+	}
+	first := datagovsg.Location{Longitude: original[0][0], Latitude: original[0][1]}
+	raffles := datagovsg.Location{Longitude: 103.8515, Latitude: 1.2838}
+
+	tests := []struct {
+		name     string
+		filter   func() *datagovsg.TaxiAvailabilityResult
+		keep     func(longitude, latitude float64) bool
+		expected int
+	}{
+		{
+			name: "bounding box",
+			filter: func() *datagovsg.TaxiAvailabilityResult {
+				return resp.WithinBoundingBox([4]float64{103.84, 1.27, 103.86, 1.29})
+			},
+			keep:     func(lng, lat float64) bool { return lng >= 103.84 && lat >= 1.27 && lng <= 103.86 && lat <= 1.29 },
+			expected: 279,
+		},
+		{
+			name: "bounding box edges",
+			filter: func() *datagovsg.TaxiAvailabilityResult {
+				return resp.WithinBoundingBox([4]float64{first.Longitude, first.Latitude, first.Longitude, first.Latitude})
+			},
+			keep:     func(lng, lat float64) bool { return lng == first.Longitude && lat == first.Latitude },
+			expected: 1,
+		},
+		{
+			name:   "radius",
+			filter: func() *datagovsg.TaxiAvailabilityResult { return resp.WithinRadius(raffles, 500) },
+			keep: func(lng, lat float64) bool {
+				return raffles.DistanceTo(datagovsg.Location{Longitude: lng, Latitude: lat}) <= 500
+			},
+			expected: 44,
+		},
+		{
+			// the first taxi, in Tuas, is the furthest from Raffles Place
+			name: "radius edge",
+			filter: func() *datagovsg.TaxiAvailabilityResult {
+				return resp.WithinRadius(raffles, raffles.DistanceTo(first))
+			},
+			keep: func(lng, lat float64) bool {
+				return raffles.DistanceTo(datagovsg.Location{Longitude: lng, Latitude: lat}) <= raffles.DistanceTo(first)
+			},
+			expected: 7290,
+		},
+		{
+			name: "nothing",
+			filter: func() *datagovsg.TaxiAvailabilityResult {
+				return resp.Filter(func(lng, lat float64) bool { return false })
+			},
+			keep:     func(lng, lat float64) bool { return false },
+			expected: 0,
+		},
+	}
+	for _, test := range tests {
+		filtered := test.filter()
+		expected := [][]float64{}
+		for _, position := range original {
+			if test.keep(position[0], position[1]) {
+				expected = append(expected, position)
+			}
+		}
+		coordinates := filtered.Coordinates()
+		if len(coordinates) != test.expected || !reflect.DeepEqual(coordinates, expected) {
+			t.Errorf("%v: expected %v taxis, got %v", test.name, test.expected, len(coordinates))
+		}
+		if count := filtered.Features[0].Properties.TaxiCount; count != len(coordinates) {
+			t.Errorf("%v: expected a taxi_count of %v, got %v", test.name, len(coordinates), count)
+		}
+		if graphQL := filtered.ToGraphQL().(datagovsg.TaxiAvailabilityResultGraphQL); graphQL.TaxiCount != len(coordinates) {
+			t.Errorf("%v: expected a GraphQL taxi_count of %v, got %v", test.name, len(coordinates), graphQL.TaxiCount)
+		}
+		if filtered.Timestamp() != resp.Timestamp() {
+			t.Errorf("%v: expected the timestamp to be kept, got %q", test.name, filtered.Timestamp())
+		}
+	}
+
+	// the original, which may be shared through the Client, is left untouched
+	if coordinates := resp.Coordinates(); resp.Features[0].Properties.TaxiCount != 7290 || !reflect.DeepEqual(coordinates, original) {
+		t.Errorf("expected the original result to be unchanged, got %v taxis", len(coordinates))
+	}
+}
@@ -0,0 +1,90 @@
+package datagovsg
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// CameraInfo describes where a traffic camera is and which way it faces
+type CameraInfo struct {
+	CameraID    int    `json:"camera_id"`
+	Expressway  string `json:"expressway"`
+	Road        string `json:"road"`
+	Direction   string `json:"direction"`
+	Description string `json:"description"`
+}
+
+// CameraCatalogue maps camera ids to their location descriptions
+type CameraCatalogue struct {
+	Version string             `json:"version"`
+	Cameras map[int]CameraInfo `json:"-"`
+}
+
+// Camera returns the catalogue entry for a camera id, or nil if it is not catalogued
+func (c *CameraCatalogue) Camera(id int) *CameraInfo {
+	if c == nil {
+		return nil
+	}
+	if info, ok := c.Cameras[id]; ok {
+		return &info
+	}
+	return nil
+}
+
+// Expressways returns the distinct expressway codes in the catalogue
+func (c *CameraCatalogue) Expressways() []string {
+	seen := map[string]bool{}
+	expressways := []string{}
+	for _, info := range c.Cameras {
+		if info.Expressway != "" && !seen[info.Expressway] {
+			seen[info.Expressway] = true
+			expressways = append(expressways, info.Expressway)
+		}
+	}
+	return expressways
+}
+
+// LoadCameraCatalogue reads a catalogue in the form {"version": "...", "cameras": [{"camera_id": 1001, ...}]}
+func LoadCameraCatalogue(r io.Reader) (*CameraCatalogue, error) {
+	var doc struct {
+		Version string       `json:"version"`
+		Cameras []CameraInfo `json:"cameras"`
+	}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return NewCameraCatalogue(doc.Version, doc.Cameras), nil
+}
+
+// NewCameraCatalogue returns a catalogue of the given cameras
+func NewCameraCatalogue(version string, cameras []CameraInfo) *CameraCatalogue {
+	c := &CameraCatalogue{
+		Version: version,
+		Cameras: map[int]CameraInfo{},
+	}
+	for _, info := range cameras {
+		c.Cameras[info.CameraID] = info
+	}
+	return c
+}
+
+// FilterByExpressway returns a copy of the result keeping only cameras on the given expressway
+func (resp *TrafficImagesResult) FilterByExpressway(catalogue *CameraCatalogue, expressway string) *TrafficImagesResult {
+	filtered := &TrafficImagesResult{
+		APIInfo: resp.APIInfo,
+		Items:   []TrafficImagesResultItem{},
+	}
+	for _, item := range resp.Items {
+		cameras := []TrafficImageCamera{}
+		for _, camera := range item.Cameras {
+			if info := catalogue.Camera(camera.CameraID); info != nil && info.Expressway == expressway {
+				cameras = append(cameras, camera)
+			}
+		}
+		filtered.Items = append(filtered.Items, TrafficImagesResultItem{
+			Timestamp: item.Timestamp,
+			Cameras:   cameras,
+		})
+	}
+	return filtered
+}
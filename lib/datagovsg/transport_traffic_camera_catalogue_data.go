@@ -0,0 +1,72 @@
+package datagovsg
+
+import (
+	"strings"
+)
+
+// DefaultCameraCatalogue describes the cameras published by the traffic-images endpoint, and is replaced by the
+// catalogue file given in the configuration, if any.
+// The embedded catalogue only covers the cameras seen in the traffic-images sample. Directions are left empty where
+// they are not known; load a catalogue with LoadCameraCatalogue for them.
+var DefaultCameraCatalogue *CameraCatalogue
+
+func init() {
+	var err error
+	DefaultCameraCatalogue, err = LoadCameraCatalogue(strings.NewReader(cameraCatalogueJSON))
+	if err != nil {
+		panic(err)
+	}
+}
+
+const cameraCatalogueJSON = `{"version": "2016-12-01", "cameras": [
+	{"camera_id": 1001, "expressway": "ECP", "road": "East Coast Parkway", "description": "ECP / Sheares Bridge"},
+	{"camera_id": 1002, "expressway": "ECP", "road": "East Coast Parkway", "description": "ECP / Fort Road"},
+	{"camera_id": 1003, "expressway": "ECP", "road": "East Coast Parkway", "description": "ECP / Marine Parade Flyover"},
+	{"camera_id": 1004, "expressway": "ECP", "road": "East Coast Parkway", "description": "ECP / Tanjong Katong Flyover"},
+	{"camera_id": 1005, "expressway": "ECP", "road": "East Coast Parkway", "description": "ECP / Still Road South"},
+	{"camera_id": 1006, "expressway": "ECP", "road": "East Coast Parkway", "description": "ECP / Bedok South"},
+	{"camera_id": 3793, "expressway": "ECP", "road": "East Coast Parkway", "description": "ECP / Marina Bay"},
+	{"camera_id": 3795, "expressway": "ECP", "road": "East Coast Parkway", "description": "ECP / Benjamin Sheares Bridge"},
+	{"camera_id": 3796, "expressway": "ECP", "road": "East Coast Parkway", "description": "ECP / Tanjong Rhu"},
+	{"camera_id": 3797, "expressway": "ECP", "road": "East Coast Parkway", "description": "ECP / Mountbatten Road"},
+	{"camera_id": 3798, "expressway": "ECP", "road": "East Coast Parkway", "description": "ECP / Changi Airport"},
+	{"camera_id": 1502, "expressway": "MCE", "road": "Marina Coastal Expressway", "description": "MCE / Marina Coastal Drive"},
+	{"camera_id": 1503, "expressway": "MCE", "road": "Marina Coastal Expressway", "description": "MCE / Sheares Avenue"},
+	{"camera_id": 1701, "expressway": "CTE", "road": "Central Expressway", "description": "CTE / Chin Swee Road"},
+	{"camera_id": 1702, "expressway": "CTE", "road": "Central Expressway", "description": "CTE / Bukit Timah Road"},
+	{"camera_id": 1703, "expressway": "CTE", "road": "Central Expressway", "description": "CTE / Moulmein Flyover"},
+	{"camera_id": 1705, "expressway": "CTE", "road": "Central Expressway", "description": "CTE / PIE Flyover"},
+	{"camera_id": 2701, "expressway": "BKE", "road": "Woodlands Causeway", "direction": "Towards Johor", "description": "Woodlands Causeway"},
+	{"camera_id": 2702, "expressway": "BKE", "road": "Woodlands Checkpoint", "direction": "Towards BKE", "description": "Woodlands Checkpoint"},
+	{"camera_id": 2703, "expressway": "BKE", "road": "Bukit Timah Expressway", "description": "BKE / Woodlands Road"},
+	{"camera_id": 2704, "expressway": "BKE", "road": "Bukit Timah Expressway", "description": "BKE / Woodlands Flyover"},
+	{"camera_id": 2705, "expressway": "BKE", "road": "Bukit Timah Expressway", "description": "BKE / Mandai Road"},
+	{"camera_id": 4701, "expressway": "AYE", "road": "Ayer Rajah Expressway", "description": "AYE / Keppel Viaduct"},
+	{"camera_id": 4702, "expressway": "AYE", "road": "Ayer Rajah Expressway", "description": "AYE / Lower Delta Road"},
+	{"camera_id": 4703, "expressway": "AYE", "road": "Tuas Second Link", "direction": "Towards Johor", "description": "Tuas Second Link"},
+	{"camera_id": 4704, "expressway": "AYE", "road": "Ayer Rajah Expressway", "description": "AYE / Alexandra Road"},
+	{"camera_id": 4706, "expressway": "AYE", "road": "Ayer Rajah Expressway", "description": "AYE / Dover Road"},
+	{"camera_id": 4708, "expressway": "AYE", "road": "Ayer Rajah Expressway", "description": "AYE / Jurong Town Hall Road"},
+	{"camera_id": 4710, "expressway": "AYE", "road": "Ayer Rajah Expressway", "description": "AYE / Jalan Ahmad Ibrahim"},
+	{"camera_id": 4712, "expressway": "AYE", "road": "Ayer Rajah Expressway", "description": "AYE / Pioneer Road"},
+	{"camera_id": 4713, "expressway": "AYE", "road": "Tuas Checkpoint", "description": "Tuas Checkpoint"},
+	{"camera_id": 4798, "expressway": "AYE", "road": "Sentosa Gateway", "direction": "Towards Sentosa", "description": "Sentosa Gateway"},
+	{"camera_id": 4799, "expressway": "AYE", "road": "Sentosa Gateway", "direction": "Towards City", "description": "Sentosa Gateway / Keppel Road"},
+	{"camera_id": 5794, "expressway": "PIE", "road": "Pan Island Expressway", "description": "PIE / Bedok North"},
+	{"camera_id": 5795, "expressway": "PIE", "road": "Pan Island Expressway", "description": "PIE / Eunos Flyover"},
+	{"camera_id": 5797, "expressway": "PIE", "road": "Pan Island Expressway", "description": "PIE / Paya Lebar Flyover"},
+	{"camera_id": 5798, "expressway": "PIE", "road": "Pan Island Expressway", "description": "PIE / Kallang Way Flyover"},
+	{"camera_id": 5799, "expressway": "PIE", "road": "Pan Island Expressway", "description": "PIE / Woodsville Flyover"},
+	{"camera_id": 6701, "expressway": "PIE", "road": "Pan Island Expressway", "description": "PIE / Kim Keat Link"},
+	{"camera_id": 6703, "expressway": "PIE", "road": "Pan Island Expressway", "description": "PIE / Thomson Flyover"},
+	{"camera_id": 6704, "expressway": "PIE", "road": "Pan Island Expressway", "description": "PIE / Mount Pleasant"},
+	{"camera_id": 6705, "expressway": "PIE", "road": "Pan Island Expressway", "description": "PIE / Adam Road"},
+	{"camera_id": 6706, "expressway": "PIE", "road": "Pan Island Expressway", "description": "PIE / BKE Interchange"},
+	{"camera_id": 6708, "expressway": "PIE", "road": "Pan Island Expressway", "description": "PIE / Toh Guan Road"},
+	{"camera_id": 7791, "expressway": "TPE", "road": "Tampines Expressway", "description": "TPE / Upper Changi Road North"},
+	{"camera_id": 7796, "expressway": "TPE", "road": "Tampines Expressway", "description": "TPE / Seletar West Link"},
+	{"camera_id": 7798, "expressway": "TPE", "road": "Tampines Expressway", "description": "TPE / SLE Interchange"},
+	{"camera_id": 8701, "expressway": "KJE", "road": "Kranji Expressway", "description": "KJE / Choa Chu Kang West Flyover"},
+	{"camera_id": 9701, "expressway": "SLE", "road": "Seletar Expressway", "description": "SLE / Woodlands Avenue 2"},
+	{"camera_id": 9703, "expressway": "SLE", "road": "Seletar Expressway", "description": "SLE / Upper Thomson Road"}
+]}`
@@ -0,0 +1,57 @@
+package datagovsg_test
+
+import (
+	"encoding/json"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestDefaultCameraCatalogue_CoversSample(t *testing.T) {
+	data, err := ioutil.ReadFile("./sample/transport_traffic_images.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := datagovsg.TrafficImagesResult{}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		t.Fatal(err)
+	}
+	cameras := resp.Cameras()
+	if len(cameras) == 0 {
+		t.Fatal("expected cameras in the sample")
+	}
+	for _, camera := range cameras {
+		info := datagovsg.DefaultCameraCatalogue.Camera(camera.CameraID)
+		if info == nil {
+			t.Errorf("camera %v is not in the catalogue", camera.CameraID)
+			continue
+		}
+		if info.Expressway == "" || info.Road == "" || info.Description == "" {
+			t.Errorf("camera %v has an incomplete entry: %+v", camera.CameraID, info)
+		}
+	}
+	if len(datagovsg.DefaultCameraCatalogue.Cameras) != len(cameras) {
+		t.Errorf("expected the catalogue to only have the %v cameras in the sample, got %v",
+			len(cameras), len(datagovsg.DefaultCameraCatalogue.Cameras))
+	}
+}
+
+func TestLoadCameraCatalogue(t *testing.T) {
+	c, err := datagovsg.LoadCameraCatalogue(strings.NewReader(`{"version": "2017-01-01", "cameras": [
+		{"camera_id": 1001, "expressway": "ECP", "road": "East Coast Parkway", "direction": "Towards Changi", "description": "ECP / Sheares Bridge"}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info := c.Camera(1001); c.Version != "2017-01-01" || info == nil || info.Direction != "Towards Changi" {
+		t.Fatalf("unexpected catalogue %+v", c)
+	}
+	if c.Camera(1002) != nil {
+		t.Fatal("expected no entry for an uncatalogued camera")
+	}
+
+	if _, err := datagovsg.LoadCameraCatalogue(strings.NewReader(`{"cameras": {}}`)); err == nil {
+		t.Fatal("expected an error for a malformed catalogue")
+	}
+}
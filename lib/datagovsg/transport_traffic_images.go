@@ -1,5 +1,9 @@
 package datagovsg
 
+import (
+	"sort"
+)
+
 type TrafficImagesOptions struct {
 	DateTime string `json:"date_time,omitempy" url:"date_time,omitempy"`
 }
@@ -29,6 +33,57 @@ type TrafficImagesResult struct {
 	Items   []TrafficImagesResultItem `json:"items,omitempty"`
 }
 
+// Cameras returns the cameras from the latest item of the result
+func (resp *TrafficImagesResult) Cameras() []TrafficImageCamera {
+	if resp == nil || len(resp.Items) == 0 {
+		return []TrafficImageCamera{}
+	}
+	return resp.Items[len(resp.Items)-1].Cameras
+}
+
+// CameraByID returns the camera with the given camera_id, or nil if there is none
+func (resp *TrafficImagesResult) CameraByID(id int) *TrafficImageCamera {
+	for _, camera := range resp.Cameras() {
+		if camera.CameraID == id {
+			return &camera
+		}
+	}
+	return nil
+}
+
+// CamerasNear returns the cameras within radius (in metres) of loc, nearest first
+func (resp *TrafficImagesResult) CamerasNear(loc Location, radius float64) []TrafficImageCamera {
+	return filterCamerasByDistance(resp.Cameras(), radius, loc.DistanceTo)
+}
+
+// CamerasAlong returns the cameras within buffer (in metres) of a route, nearest to the route first
+func (resp *TrafficImagesResult) CamerasAlong(route []Location, buffer float64) []TrafficImageCamera {
+	return filterCamerasByDistance(resp.Cameras(), buffer, func(loc Location) float64 {
+		return loc.DistanceToLine(route)
+	})
+}
+
+func filterCamerasByDistance(cameras []TrafficImageCamera, max float64, distance func(Location) float64) []TrafficImageCamera {
+	type cameraDistance struct {
+		camera   TrafficImageCamera
+		distance float64
+	}
+	matches := []cameraDistance{}
+	for _, camera := range cameras {
+		if d := distance(camera.Location); d <= max {
+			matches = append(matches, cameraDistance{camera, d})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+	result := []TrafficImageCamera{}
+	for _, m := range matches {
+		result = append(result, m.camera)
+	}
+	return result
+}
+
 func (resp *TrafficImagesResult) ToGraphQL() interface{} {
 	return resp
 }
@@ -0,0 +1,66 @@
+package datagovsg_test
+
+import (
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"reflect"
+	"testing"
+)
+
+func cameraIDs(cameras []datagovsg.TrafficImageCamera) []int {
+	ids := []int{}
+	for _, camera := range cameras {
+		ids = append(ids, camera.CameraID)
+	}
+	return ids
+}
+
+func TestTrafficImagesResult_CamerasNear(t *testing.T) {
+	resp := &datagovsg.TrafficImagesResult{}
+	loadSample(t, "transport_traffic_images", resp)
+	raffles := datagovsg.Location{Latitude: 1.2838, Longitude: 103.8515}
+
+	tests := []struct {
+		radius   float64
+		expected []int
+	}{
+		{1000, []int{}},
+		{2500, []int{3798, 1503, 1502, 4702}},
+		// exactly the distance to camera 1001
+		{raffles.DistanceTo(resp.CameraByID(1001).Location), []int{3798, 1503, 1502, 4702, 1001}},
+	}
+	for _, test := range tests {
+		cameras := resp.CamerasNear(raffles, test.radius)
+		if ids := cameraIDs(cameras); !reflect.DeepEqual(ids, test.expected) {
+			t.Errorf("within %.0fm: expected cameras %v, got %v", test.radius, test.expected, ids)
+		}
+		for i := 1; i < len(cameras); i++ {
+			if raffles.DistanceTo(cameras[i-1].Location) > raffles.DistanceTo(cameras[i].Location) {
+				t.Errorf("within %.0fm: expected cameras nearest first, got %v", test.radius, cameraIDs(cameras))
+			}
+		}
+	}
+}
+
+func TestTrafficImagesResult_CamerasAlong(t *testing.T) {
+	resp := &datagovsg.TrafficImagesResult{}
+	loadSample(t, "transport_traffic_images", resp)
+
+	// along the ECP, between two of its cameras
+	route := []datagovsg.Location{resp.CameraByID(3798).Location, resp.CameraByID(3793).Location}
+	tests := []struct {
+		buffer   float64
+		expected []int
+	}{
+		{0, []int{3793, 3798}},
+		{100, []int{3793, 3798, 3797}},
+		{300, []int{3793, 3798, 3797, 1001, 3795}},
+	}
+	for _, test := range tests {
+		if ids := cameraIDs(resp.CamerasAlong(route, test.buffer)); !reflect.DeepEqual(ids, test.expected) {
+			t.Errorf("within %.0fm: expected cameras %v, got %v", test.buffer, test.expected, ids)
+		}
+	}
+	if cameras := resp.CamerasAlong(nil, 1000); len(cameras) != 0 {
+		t.Errorf("expected no cameras along an empty route, got %v", cameraIDs(cameras))
+	}
+}
@@ -0,0 +1,278 @@
+package imageproxy
+
+import (
+	"container/list"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/go-querystring/query"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var ErrCameraNotFound = errors.New("camera not found")
+var ErrChecksumMismatch = errors.New("image does not match image_metadata.md5")
+var ErrImageTooLarge = errors.New("image is larger than the maximum image size")
+
+// MaxImageBytes is the largest image Download reads. Traffic camera images are well under 1MB.
+var MaxImageBytes int64 = 8 << 20
+
+// Options for resizing and re-encoding an image. Zero values leave the original untouched.
+type Options struct {
+	Width   int
+	Height  int
+	Quality int
+}
+
+func (o Options) isZero() bool {
+	return o.Width == 0 && o.Height == 0 && o.Quality == 0
+}
+
+// Image is a cached traffic camera image
+type Image struct {
+	CameraID  int
+	Timestamp string
+	MD5       string
+	Data      []byte
+}
+
+// Proxy fetches traffic camera images from data.gov.sg, verifies them against their md5 checksum and keeps
+// the most recently used ones in memory
+type Proxy struct {
+	APIKey string
+
+	// CamerasTTL is how long the list of cameras is reused before it is fetched again
+	CamerasTTL time.Duration
+
+	// MaxEntries is the maximum number of images (including resized variants) kept in memory
+	MaxEntries int
+
+	// HTTPClient fetches the list of cameras and the images
+	HTTPClient *http.Client
+
+	camerasLock      sync.Mutex
+	cameras          map[int]datagovsg.TrafficImageCamera
+	camerasFetchedAt time.Time
+	camerasRefresh   *camerasRefresh
+
+	cacheLock sync.Mutex
+	cache     map[string]*list.Element
+	lru       *list.List
+}
+
+// New returns a new Proxy
+func New(apiKey string) *Proxy {
+	return &Proxy{
+		APIKey:     apiKey,
+		CamerasTTL: 20 * time.Second,
+		MaxEntries: 256,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		cache:      map[string]*list.Element{},
+		lru:        list.New(),
+	}
+}
+
+// Camera returns the latest metadata for a camera
+func (p *Proxy) Camera(id int) (datagovsg.TrafficImageCamera, error) {
+	cameras, err := p.cameraList()
+	if err != nil {
+		return datagovsg.TrafficImageCamera{}, err
+	}
+	camera, ok := cameras[id]
+	if !ok {
+		return camera, ErrCameraNotFound
+	}
+	return camera, nil
+}
+
+// camerasRefresh is a fetch of the list of cameras in progress, which callers wait on until done is closed
+type camerasRefresh struct {
+	done    chan struct{}
+	cameras map[int]datagovsg.TrafficImageCamera
+	err     error
+}
+
+// cameraList returns the cameras by id, fetching them again if they are older than CamerasTTL.
+// The fetch is made without holding camerasLock, and callers that need the list meanwhile wait for the same fetch.
+func (p *Proxy) cameraList() (map[int]datagovsg.TrafficImageCamera, error) {
+	p.camerasLock.Lock()
+	if p.cameras != nil && time.Since(p.camerasFetchedAt) <= p.CamerasTTL {
+		cameras := p.cameras
+		p.camerasLock.Unlock()
+		return cameras, nil
+	}
+	refresh := p.camerasRefresh
+	if refresh != nil {
+		p.camerasLock.Unlock()
+		<-refresh.done
+		return refresh.cameras, refresh.err
+	}
+	refresh = &camerasRefresh{done: make(chan struct{})}
+	p.camerasRefresh = refresh
+	p.camerasLock.Unlock()
+
+	refresh.cameras, refresh.err = p.fetchCameras()
+
+	p.camerasLock.Lock()
+	if refresh.err == nil {
+		p.cameras = refresh.cameras
+		p.camerasFetchedAt = time.Now()
+	}
+	p.camerasRefresh = nil
+	p.camerasLock.Unlock()
+	close(refresh.done)
+	return refresh.cameras, refresh.err
+}
+
+func (p *Proxy) fetchCameras() (map[int]datagovsg.TrafficImageCamera, error) {
+	client := datagovsg.NewClient(p.APIKey)
+	client.HTTPClient = p.HTTPClient
+	v, _ := query.Values(datagovsg.TrafficImagesOptions{})
+	res := <-client.Get(
+		fmt.Sprintf("https://api.data.gov.sg/v1/transport/traffic-images?%v", v.Encode()),
+		&datagovsg.TrafficImagesResult{},
+	)
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	resp, _ := res.Body.(*datagovsg.TrafficImagesResult)
+	cameras := map[int]datagovsg.TrafficImageCamera{}
+	for _, camera := range resp.Cameras() {
+		cameras[camera.CameraID] = camera
+	}
+	return cameras, nil
+}
+
+// Image returns the latest image for a camera, resized and re-encoded according to opts
+func (p *Proxy) Image(cameraID int, opts Options) (*Image, error) {
+	camera, err := p.Camera(cameraID)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := p.original(camera)
+	if err != nil {
+		return nil, err
+	}
+	if opts.isZero() {
+		return original, nil
+	}
+
+	key := fmt.Sprintf("%v/%vx%v/q%v", original.MD5, opts.Width, opts.Height, opts.Quality)
+	if img := p.get(key); img != nil {
+		return img, nil
+	}
+	data, err := Resize(original.Data, opts)
+	if err != nil {
+		return nil, err
+	}
+	img := &Image{
+		CameraID:  original.CameraID,
+		Timestamp: original.Timestamp,
+		MD5:       original.MD5,
+		Data:      data,
+	}
+	p.put(key, img)
+	return img, nil
+}
+
+// original returns the camera image as published, downloading and verifying it if it is not cached yet
+func (p *Proxy) original(camera datagovsg.TrafficImageCamera) (*Image, error) {
+	key := camera.ImageMetadata.MD5
+	if img := p.get(key); img != nil {
+		return img, nil
+	}
+
+	data, err := Download(p.HTTPClient, camera.Image, camera.ImageMetadata.MD5)
+	if err != nil {
+		return nil, err
+	}
+	img := &Image{
+		CameraID:  camera.CameraID,
+		Timestamp: camera.Timestamp,
+		MD5:       camera.ImageMetadata.MD5,
+		Data:      data,
+	}
+	p.put(key, img)
+	return img, nil
+}
+
+// Download fetches an image and verifies it against the expected md5 checksum (if given).
+// Images over MaxImageBytes are not read, and return ErrImageTooLarge.
+func Download(client *http.Client, url string, expectedMD5 string) ([]byte, error) {
+	res, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %v: %v", url, res.Status)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(res.Body, MaxImageBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > MaxImageBytes {
+		return nil, ErrImageTooLarge
+	}
+	if expectedMD5 != "" {
+		sum := md5.Sum(data)
+		if hex.EncodeToString(sum[:]) != expectedMD5 {
+			return nil, ErrChecksumMismatch
+		}
+	}
+	return data, nil
+}
+
+func (p *Proxy) get(key string) *Image {
+	p.cacheLock.Lock()
+	defer p.cacheLock.Unlock()
+	if el, ok := p.cache[key]; ok {
+		p.lru.MoveToFront(el)
+		return el.Value.(*cacheEntry).image
+	}
+	return nil
+}
+
+func (p *Proxy) put(key string, img *Image) {
+	p.cacheLock.Lock()
+	defer p.cacheLock.Unlock()
+	if el, ok := p.cache[key]; ok {
+		p.lru.MoveToFront(el)
+		el.Value.(*cacheEntry).image = img
+		return
+	}
+	p.cache[key] = p.lru.PushFront(&cacheEntry{key, img})
+	for p.lru.Len() > p.MaxEntries {
+		oldest := p.lru.Back()
+		p.lru.Remove(oldest)
+		delete(p.cache, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+type cacheEntry struct {
+	key   string
+	image *Image
+}
+
+// ServeImage writes an image as an HTTP response, honouring If-None-Match
+func ServeImage(w http.ResponseWriter, r *http.Request, img *Image, maxAge time.Duration) {
+	sum := md5.Sum(img.Data)
+	etag := fmt.Sprintf(`"%v"`, hex.EncodeToString(sum[:]))
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+	w.Header().Set("X-Camera-Timestamp", img.Timestamp)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.Header().Set("Content-Length", fmt.Sprint(len(img.Data)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(img.Data)
+}
@@ -0,0 +1,201 @@
+package imageproxy_test
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/imageproxy"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testJPEG returns a width x height gradient encoded as a JPEG
+func testJPEG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 100, 255})
+		}
+	}
+	buf := bytes.Buffer{}
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func md5Hex(data []byte) string {
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// upstream serves the traffic-images API with a single camera 1001, and its image, counting the requests made
+type upstream struct {
+	image []byte
+	md5   string
+	delay time.Duration
+
+	cameraRequests int32
+	imageRequests  int32
+}
+
+func (u *upstream) RoundTrip(r *http.Request) (*http.Response, error) {
+	body := u.image
+	if strings.HasSuffix(r.URL.Path, "/traffic-images") {
+		atomic.AddInt32(&u.cameraRequests, 1)
+		time.Sleep(u.delay)
+		body = []byte(fmt.Sprintf(`{"items": [{"timestamp": "2016-12-01T10:00:00+08:00", "cameras": [{
+			"timestamp": "2016-12-01T10:00:00+08:00",
+			"image": "https://images.data.gov.sg/api/traffic-images/2016/12/1001.jpg",
+			"camera_id": 1001,
+			"image_metadata": {"height": 240, "width": 320, "md5": %q}
+		}]}], "api_info": {"status": "healthy"}}`, u.md5))
+	} else {
+		atomic.AddInt32(&u.imageRequests, 1)
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		Header:     http.Header{},
+	}, nil
+}
+
+func newProxy(u *upstream) *imageproxy.Proxy {
+	p := imageproxy.New("")
+	p.HTTPClient = &http.Client{Transport: u, Timeout: time.Second}
+	return p
+}
+
+func TestDownload_MD5(t *testing.T) {
+	data := testJPEG(t, 32, 24)
+	client := &http.Client{Transport: &upstream{image: data}}
+
+	if _, err := imageproxy.Download(client, "https://images.data.gov.sg/1001.jpg", md5Hex(data)); err != nil {
+		t.Fatalf("expected matching image to download, got %v", err)
+	}
+	if _, err := imageproxy.Download(client, "https://images.data.gov.sg/1001.jpg", ""); err != nil {
+		t.Fatalf("expected image without a checksum to download, got %v", err)
+	}
+	if _, err := imageproxy.Download(client, "https://images.data.gov.sg/1001.jpg", md5Hex([]byte("other"))); err != imageproxy.ErrChecksumMismatch {
+		t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+	}
+}
+
+func TestDownload_MaxImageBytes(t *testing.T) {
+	data := testJPEG(t, 32, 24)
+	client := &http.Client{Transport: &upstream{image: data}}
+	maxImageBytes := imageproxy.MaxImageBytes
+	defer func() { imageproxy.MaxImageBytes = maxImageBytes }()
+
+	imageproxy.MaxImageBytes = int64(len(data))
+	if downloaded, err := imageproxy.Download(client, "https://images.data.gov.sg/1001.jpg", md5Hex(data)); err != nil || !bytes.Equal(downloaded, data) {
+		t.Fatalf("expected an image at the limit to download, got %v", err)
+	}
+	imageproxy.MaxImageBytes = int64(len(data)) - 1
+	if _, err := imageproxy.Download(client, "https://images.data.gov.sg/1001.jpg", ""); err != imageproxy.ErrImageTooLarge {
+		t.Fatalf("expected ErrImageTooLarge, got %v", err)
+	}
+}
+
+func TestProxy_Image(t *testing.T) {
+	data := testJPEG(t, 320, 240)
+	u := &upstream{image: data, md5: md5Hex(data)}
+	p := newProxy(u)
+
+	img, err := p.Image(1001, imageproxy.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(img.Data, data) || img.MD5 != u.md5 || img.Timestamp != "2016-12-01T10:00:00+08:00" {
+		t.Fatalf("expected the original image, got %+v", img)
+	}
+
+	resized, err := p.Image(1001, imageproxy.Options{Width: 160})
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := jpeg.Decode(bytes.NewReader(resized.Data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bounds := decoded.Bounds(); bounds.Dx() != 160 || bounds.Dy() != 120 {
+		t.Fatalf("expected a 160x120 image, got %v", bounds)
+	}
+	if n := atomic.LoadInt32(&u.imageRequests); n != 1 {
+		t.Fatalf("expected the original to be downloaded once, got %v", n)
+	}
+
+	if _, err := p.Image(1002, imageproxy.Options{}); err != imageproxy.ErrCameraNotFound {
+		t.Fatalf("expected ErrCameraNotFound, got %v", err)
+	}
+	if n := atomic.LoadInt32(&u.cameraRequests); n != 1 {
+		t.Fatalf("expected cameras to be fetched once within CamerasTTL, got %v", n)
+	}
+}
+
+func TestProxy_ImageChecksumMismatch(t *testing.T) {
+	u := &upstream{image: testJPEG(t, 32, 24), md5: md5Hex([]byte("other"))}
+	if _, err := newProxy(u).Image(1001, imageproxy.Options{}); err != imageproxy.ErrChecksumMismatch {
+		t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+	}
+}
+
+func TestProxy_LRU(t *testing.T) {
+	data := testJPEG(t, 320, 240)
+	u := &upstream{image: data, md5: md5Hex(data)}
+	p := newProxy(u)
+	p.MaxEntries = 2
+
+	// the original and one resized variant fit
+	first, _ := p.Image(1001, imageproxy.Options{Width: 100})
+	if again, _ := p.Image(1001, imageproxy.Options{Width: 100}); again != first {
+		t.Fatal("expected the resized variant to be cached")
+	}
+
+	// a second variant evicts the least recently used entry: the first variant, as the original was just used
+	p.Image(1001, imageproxy.Options{Width: 50})
+	if again, _ := p.Image(1001, imageproxy.Options{Width: 100}); again == first {
+		t.Fatal("expected the first variant to be evicted")
+	}
+	if n := atomic.LoadInt32(&u.imageRequests); n != 1 {
+		t.Fatalf("expected the original to stay cached, got %v downloads", n)
+	}
+
+	// with room for one entry, the variant evicts the original
+	p.MaxEntries = 1
+	p.Image(1001, imageproxy.Options{Width: 25})
+	p.Image(1001, imageproxy.Options{})
+	if n := atomic.LoadInt32(&u.imageRequests); n != 2 {
+		t.Fatalf("expected the evicted original to be downloaded again, got %v downloads", n)
+	}
+}
+
+func TestProxy_CamerasRefreshedOnce(t *testing.T) {
+	data := testJPEG(t, 32, 24)
+	u := &upstream{image: data, md5: md5Hex(data), delay: 50 * time.Millisecond}
+	p := newProxy(u)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := p.Camera(1001); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := atomic.LoadInt32(&u.cameraRequests); n != 1 {
+		t.Fatalf("expected concurrent callers to share one fetch of the cameras, got %v", n)
+	}
+}
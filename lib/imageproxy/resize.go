@@ -0,0 +1,123 @@
+package imageproxy
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+)
+
+// MaxDimension is the largest width or height an image can be resized to
+const MaxDimension = 2048
+
+// Validate checks that the options are within range
+func (o Options) Validate() error {
+	if o.Width < 0 || o.Height < 0 || o.Width > MaxDimension || o.Height > MaxDimension {
+		return fmt.Errorf("width and height must be between 0 and %v", MaxDimension)
+	}
+	if o.Quality < 0 || o.Quality > 100 {
+		return fmt.Errorf("quality must be between 0 and 100")
+	}
+	return nil
+}
+
+// Resize decodes an image, scales it to fit opts.Width x opts.Height and encodes it as a JPEG with opts.Quality.
+// If only one of width or height is given, the other is derived from the aspect ratio.
+func Resize(data []byte, opts Options) ([]byte, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	width, height := opts.Width, opts.Height
+	switch {
+	case width == 0 && height == 0:
+		width, height = bounds.Dx(), bounds.Dy()
+	case width == 0:
+		width = bounds.Dx() * height / bounds.Dy()
+	case height == 0:
+		height = bounds.Dy() * width / bounds.Dx()
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	dst := src
+	if width != bounds.Dx() || height != bounds.Dy() {
+		dst = scale(src, width, height)
+	}
+
+	quality := opts.Quality
+	if quality == 0 {
+		quality = jpeg.DefaultQuality
+	}
+	buf := bytes.Buffer{}
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// scale resizes an image using bilinear interpolation
+func scale(src image.Image, width, height int) image.Image {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	xRatio := float64(bounds.Dx()) / float64(width)
+	yRatio := float64(bounds.Dy()) / float64(height)
+
+	for y := 0; y < height; y++ {
+		sy := (float64(y)+0.5)*yRatio - 0.5
+		y0 := clamp(int(sy), 0, bounds.Dy()-1)
+		y1 := clamp(y0+1, 0, bounds.Dy()-1)
+		fy := sy - float64(y0)
+		if fy < 0 {
+			fy = 0
+		}
+		for x := 0; x < width; x++ {
+			sx := (float64(x)+0.5)*xRatio - 0.5
+			x0 := clamp(int(sx), 0, bounds.Dx()-1)
+			x1 := clamp(x0+1, 0, bounds.Dx()-1)
+			fx := sx - float64(x0)
+			if fx < 0 {
+				fx = 0
+			}
+
+			r00, g00, b00, a00 := src.At(bounds.Min.X+x0, bounds.Min.Y+y0).RGBA()
+			r10, g10, b10, a10 := src.At(bounds.Min.X+x1, bounds.Min.Y+y0).RGBA()
+			r01, g01, b01, a01 := src.At(bounds.Min.X+x0, bounds.Min.Y+y1).RGBA()
+			r11, g11, b11, a11 := src.At(bounds.Min.X+x1, bounds.Min.Y+y1).RGBA()
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i+0] = lerp2(r00, r10, r01, r11, fx, fy)
+			dst.Pix[i+1] = lerp2(g00, g10, g01, g11, fx, fy)
+			dst.Pix[i+2] = lerp2(b00, b10, b01, b11, fx, fy)
+			dst.Pix[i+3] = lerp2(a00, a10, a01, a11, fx, fy)
+		}
+	}
+	return dst
+}
+
+// lerp2 bilinearly interpolates four 16-bit colour components into an 8-bit one
+func lerp2(c00, c10, c01, c11 uint32, fx, fy float64) uint8 {
+	top := float64(c00)*(1-fx) + float64(c10)*fx
+	bottom := float64(c01)*(1-fx) + float64(c11)*fx
+	return uint8((top*(1-fy) + bottom*fy) / 257)
+}
+
+func clamp(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
@@ -0,0 +1,63 @@
+package imageproxy_test
+
+import (
+	"bytes"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/imageproxy"
+	"image/jpeg"
+	"testing"
+)
+
+func TestResize(t *testing.T) {
+	data := testJPEG(t, 320, 240)
+	tests := []struct {
+		opts          imageproxy.Options
+		width, height int
+	}{
+		{imageproxy.Options{Width: 160}, 160, 120},
+		{imageproxy.Options{Height: 60}, 80, 60},
+		{imageproxy.Options{Width: 100, Height: 100}, 100, 100},
+		{imageproxy.Options{Quality: 10}, 320, 240},
+		{imageproxy.Options{Width: 1000}, 1000, 750},
+		{imageproxy.Options{Height: 1}, 1, 1},
+	}
+	for _, test := range tests {
+		out, err := imageproxy.Resize(data, test.opts)
+		if err != nil {
+			t.Errorf("%+v: %v", test.opts, err)
+			continue
+		}
+		img, err := jpeg.Decode(bytes.NewReader(out))
+		if err != nil {
+			t.Errorf("%+v: %v", test.opts, err)
+			continue
+		}
+		if bounds := img.Bounds(); bounds.Dx() != test.width || bounds.Dy() != test.height {
+			t.Errorf("%+v: expected %vx%v, got %v", test.opts, test.width, test.height, bounds)
+		}
+	}
+
+	if low, _ := imageproxy.Resize(data, imageproxy.Options{Quality: 10}); len(low) >= len(data) {
+		t.Errorf("expected quality 10 to be smaller than the default quality, got %v >= %v bytes", len(low), len(data))
+	}
+	if _, err := imageproxy.Resize([]byte("not an image"), imageproxy.Options{Width: 10}); err == nil {
+		t.Error("expected an error decoding an invalid image")
+	}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		opts  imageproxy.Options
+		valid bool
+	}{
+		{imageproxy.Options{}, true},
+		{imageproxy.Options{Width: imageproxy.MaxDimension, Height: imageproxy.MaxDimension, Quality: 100}, true},
+		{imageproxy.Options{Width: -1}, false},
+		{imageproxy.Options{Height: imageproxy.MaxDimension + 1}, false},
+		{imageproxy.Options{Quality: 101}, false},
+	}
+	for _, test := range tests {
+		if err := test.opts.Validate(); (err == nil) != test.valid {
+			t.Errorf("%+v: expected valid %v, got %v", test.opts, test.valid, err)
+		}
+	}
+}
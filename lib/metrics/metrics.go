@@ -0,0 +1,195 @@
+package metrics
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var Operations = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "datagovsg",
+	Subsystem: "graphql",
+	Name:      "operations_total",
+	Help:      "GraphQL operations executed, by operation name and whether they returned errors.",
+}, []string{"operation", "status"})
+
+var OperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "datagovsg",
+	Subsystem: "graphql",
+	Name:      "operation_duration_seconds",
+	Help:      "Time taken to execute GraphQL operations, by operation name.",
+}, []string{"operation"})
+
+var RequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: "datagovsg",
+	Subsystem: "graphql",
+	Name:      "requests_in_flight",
+	Help:      "GraphQL HTTP requests being served.",
+})
+
+var ResolverDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "datagovsg",
+	Subsystem: "graphql",
+	Name:      "resolver_duration_seconds",
+	Help:      "Time taken by field resolvers, by field (Type.field). Fields without a resolver of their own are not timed.",
+}, []string{"field"})
+
+var ResultCache = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "datagovsg",
+	Subsystem: "graphql",
+	Name:      "result_cache_lookups_total",
+	Help:      "Result cache lookups, by result (hit or miss).",
+}, []string{"result"})
+
+var UpstreamRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "datagovsg",
+	Subsystem: "upstream",
+	Name:      "requests_total",
+	Help:      "Requests made to data.gov.sg, by endpoint and HTTP status (\"error\" if no response was received).",
+}, []string{"endpoint", "status"})
+
+var UpstreamDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "datagovsg",
+	Subsystem: "upstream",
+	Name:      "request_duration_seconds",
+	Help:      "Time taken by requests to data.gov.sg, by endpoint.",
+}, []string{"endpoint"})
+
+var UpstreamWaiters = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "datagovsg",
+	Subsystem: "upstream",
+	Name:      "waiters_total",
+	Help: "Requests for data.gov.sg served by another request for the same URL, by endpoint and whether that " +
+		"request was in flight or memoized. Waiters per fetch is this over requests_total.",
+}, []string{"endpoint", "source"})
+
+func init() {
+	prometheus.MustRegister(
+		Operations,
+		OperationDuration,
+		RequestsInFlight,
+		ResolverDuration,
+		ResultCache,
+		UpstreamRequests,
+		UpstreamDuration,
+		UpstreamWaiters,
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+var operationNamePattern = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]{0,63}$`)
+
+// MaxOperationNames is how many distinct operation names are used as labels. Later names are labelled "other".
+var MaxOperationNames = 100
+
+var operationNames = struct {
+	sync.Mutex
+	seen map[string]bool
+}{seen: map[string]bool{}}
+
+// OperationLabel returns the label for an operation name. Operation names are chosen by clients, so anything that
+// is not a valid GraphQL name of reasonable length is grouped together, as are names past the first MaxOperationNames.
+func OperationLabel(name string) string {
+	switch {
+	case name == "":
+		return "anonymous"
+	case !operationNamePattern.MatchString(name):
+		return "invalid"
+	}
+	operationNames.Lock()
+	defer operationNames.Unlock()
+	if !operationNames.seen[name] {
+		if len(operationNames.seen) >= MaxOperationNames {
+			return "other"
+		}
+		operationNames.seen[name] = true
+	}
+	return name
+}
+
+// ObserveOperation records a GraphQL operation that started at start
+func ObserveOperation(name string, start time.Time, result *graphql.Result) {
+	label := OperationLabel(name)
+	status := "ok"
+	if result.HasErrors() {
+		status = "error"
+	}
+	Operations.WithLabelValues(label, status).Inc()
+	OperationDuration.WithLabelValues(label).Observe(time.Since(start).Seconds())
+}
+
+// ObserveResultCache records a result cache lookup
+func ObserveResultCache(hit bool) {
+	if hit {
+		ResultCache.WithLabelValues("hit").Inc()
+	} else {
+		ResultCache.WithLabelValues("miss").Inc()
+	}
+}
+
+// InstrumentSchema wraps every field resolver of the schema's object types to record its latency.
+// It must be called once, before the schema is used.
+func InstrumentSchema(schema *graphql.Schema) {
+	for name, t := range schema.TypeMap() {
+		obj, ok := t.(*graphql.Object)
+		if !ok || strings.HasPrefix(name, "__") {
+			continue
+		}
+		for fieldName, field := range obj.Fields() {
+			if field.Resolve == nil {
+				continue
+			}
+			field.Resolve = timeResolver(ResolverDuration.WithLabelValues(name+"."+fieldName), field.Resolve)
+		}
+	}
+}
+
+func timeResolver(observer prometheus.Observer, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		start := time.Now()
+		defer func() {
+			observer.Observe(time.Since(start).Seconds())
+		}()
+		return resolve(p)
+	}
+}
+
+// UpstreamObserver records the upstream requests of datagovsg.Clients. Set it as datagovsg.DefaultObserver.
+type UpstreamObserver struct{}
+
+// endpoint returns the label for a data.gov.sg URL: its path, without query parameters
+func endpoint(rawurl string) string {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return "invalid"
+	}
+	return u.Path
+}
+
+func (UpstreamObserver) Fetched(url string, status int, duration time.Duration, err error) {
+	label := "error"
+	if status != 0 {
+		label = strconv.Itoa(status)
+	}
+	UpstreamRequests.WithLabelValues(endpoint(url), label).Inc()
+	UpstreamDuration.WithLabelValues(endpoint(url)).Observe(duration.Seconds())
+}
+
+func (UpstreamObserver) Waited(url string, memoized bool) {
+	source := "in_flight"
+	if memoized {
+		source = "memoized"
+	}
+	UpstreamWaiters.WithLabelValues(endpoint(url), source).Inc()
+}
@@ -0,0 +1,32 @@
+package metrics_test
+
+import (
+	"fmt"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/metrics"
+	"testing"
+)
+
+func TestOperationLabel(t *testing.T) {
+	for name, expected := range map[string]string{
+		"":              "anonymous",
+		"1stQuery":      "invalid",
+		"Weather Now":   "invalid",
+		"WeatherNow":    "WeatherNow",
+		"weather_now_2": "weather_now_2",
+	} {
+		if label := metrics.OperationLabel(name); label != expected {
+			t.Errorf("OperationLabel(%q): expected %q, got %q", name, expected, label)
+		}
+	}
+
+	// names past the limit are grouped, while names already seen keep their label
+	for i := 0; i < metrics.MaxOperationNames; i++ {
+		metrics.OperationLabel(fmt.Sprintf("Query%d", i))
+	}
+	if label := metrics.OperationLabel("OneTooMany"); label != "other" {
+		t.Errorf("expected a name past the limit to be labelled other, got %q", label)
+	}
+	if label := metrics.OperationLabel("WeatherNow"); label != "WeatherNow" {
+		t.Errorf("expected a name seen before the limit to keep its label, got %q", label)
+	}
+}
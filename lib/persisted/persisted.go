@@ -0,0 +1,186 @@
+package persisted
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Errors returned by Resolve. The messages of ErrNotFound and ErrNotSupported are the ones Apollo clients look for.
+var ErrNotFound = errors.New("PersistedQueryNotFound")
+var ErrNotSupported = errors.New("PersistedQueryNotSupported")
+var ErrHashMismatch = errors.New("provided sha256Hash does not match query")
+var ErrNotAllowed = errors.New("query is not in the allowlist")
+
+// MaxBodyBytes is the largest request body RequestHash reads
+var MaxBodyBytes int64 = 1 << 20
+
+// Hash returns the hex-encoded sha256 hash of a query
+func Hash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+// Cache keeps the most recently used automatic persisted queries in memory
+type Cache struct {
+	MaxEntries int
+
+	lock    sync.Mutex
+	queries map[string]*list.Element
+	lru     *list.List
+}
+
+type cacheEntry struct {
+	hash  string
+	query string
+}
+
+// NewCache returns a new Cache holding up to maxEntries queries
+func NewCache(maxEntries int) *Cache {
+	return &Cache{
+		MaxEntries: maxEntries,
+		queries:    map[string]*list.Element{},
+		lru:        list.New(),
+	}
+}
+
+func (c *Cache) get(hash string) (string, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if el, ok := c.queries[hash]; ok {
+		c.lru.MoveToFront(el)
+		return el.Value.(*cacheEntry).query, true
+	}
+	return "", false
+}
+
+func (c *Cache) put(hash string, query string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if el, ok := c.queries[hash]; ok {
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.queries[hash] = c.lru.PushFront(&cacheEntry{hash, query})
+	for c.lru.Len() > c.MaxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.queries, oldest.Value.(*cacheEntry).hash)
+	}
+}
+
+// LoadAllowlist reads an allowlist: a JSON object mapping sha256 hashes to queries.
+// Hashes are returned in lower case, as Resolve looks them up.
+func LoadAllowlist(r io.Reader) (map[string]string, error) {
+	doc := map[string]string{}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	allowlist := map[string]string{}
+	for hash, query := range doc {
+		hash = strings.ToLower(hash)
+		if Hash(query) != hash {
+			return nil, fmt.Errorf("allowlist: hash %v does not match its query", hash)
+		}
+		allowlist[hash] = query
+	}
+	return allowlist, nil
+}
+
+// Resolver turns a (hash, query) pair from a request into the query to execute
+type Resolver struct {
+	// Cache holds automatic persisted queries. Automatic persisted queries are disabled if it is nil.
+	Cache *Cache
+
+	// Allowlist maps hashes to queries. If set, only these queries are executed and Cache is not used.
+	Allowlist map[string]string
+}
+
+// Resolve returns the query to execute for a request carrying an optional persisted query hash and an optional query.
+//
+// Without an allowlist, this implements Apollo's automatic persisted queries: a hash alone is looked up in the cache
+// (ErrNotFound if it is not there), and a hash sent with its query registers the query.
+// With an allowlist, a hash must be in the allowlist, and a query sent in full must hash to one that is.
+func (r *Resolver) Resolve(hash string, query string) (string, error) {
+	hash = strings.ToLower(hash)
+	if hash != "" && query != "" && Hash(query) != hash {
+		return "", ErrHashMismatch
+	}
+
+	if r.Allowlist != nil {
+		if hash == "" {
+			hash = Hash(query)
+		}
+		allowed, ok := r.Allowlist[hash]
+		if !ok {
+			return "", ErrNotAllowed
+		}
+		return allowed, nil
+	}
+
+	if hash == "" {
+		return query, nil
+	}
+	if r.Cache == nil {
+		if query == "" {
+			return "", ErrNotSupported
+		}
+		return query, nil
+	}
+	if query == "" {
+		cached, ok := r.Cache.get(hash)
+		if !ok {
+			return "", ErrNotFound
+		}
+		return cached, nil
+	}
+	r.Cache.put(hash, query)
+	return query, nil
+}
+
+type extensions struct {
+	PersistedQuery struct {
+		Version    int    `json:"version"`
+		Sha256Hash string `json:"sha256Hash"`
+	} `json:"persistedQuery"`
+}
+
+// RequestHash returns the persisted query hash of a GraphQL HTTP request, or "" if it has none.
+// It is read from the "extensions" query parameter of GET requests, or the "extensions" field of JSON POST bodies.
+// The body is left in place to be read again. Bodies over MaxBodyBytes have no hash.
+func RequestHash(r *http.Request) string {
+	raw := r.URL.Query().Get("extensions")
+	if raw == "" && r.Method == "POST" && strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		body, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, MaxBodyBytes))
+		r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return ""
+		}
+		var req struct {
+			Extensions json.RawMessage `json:"extensions"`
+		}
+		if json.Unmarshal(body, &req) == nil {
+			raw = string(req.Extensions)
+		}
+	}
+	return ExtensionsHash([]byte(raw))
+}
+
+// ExtensionsHash returns the persisted query hash of a request's raw "extensions" object, or "" if it has none
+func ExtensionsHash(raw []byte) string {
+	ext := extensions{}
+	if len(raw) == 0 || json.Unmarshal(raw, &ext) != nil {
+		return ""
+	}
+	return ext.PersistedQuery.Sha256Hash
+}
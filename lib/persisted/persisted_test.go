@@ -0,0 +1,93 @@
+package persisted_test
+
+import (
+	"github.com/sogko/data-gov-sg-graphql-go/lib/persisted"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const query = `{ environment { uv_index { items { timestamp } } } }`
+
+func TestResolve_AutomaticPersistedQueries(t *testing.T) {
+	r := &persisted.Resolver{Cache: persisted.NewCache(10)}
+	hash := persisted.Hash(query)
+
+	if _, err := r.Resolve(hash, ""); err != persisted.ErrNotFound {
+		t.Fatalf("expected ErrNotFound for unregistered hash, got %v", err)
+	}
+	if _, err := r.Resolve(hash, query+" "); err != persisted.ErrHashMismatch {
+		t.Fatalf("expected ErrHashMismatch, got %v", err)
+	}
+	if got, err := r.Resolve(hash, query); err != nil || got != query {
+		t.Fatalf("registering query: got %q, %v", got, err)
+	}
+	if got, err := r.Resolve(hash, ""); err != nil || got != query {
+		t.Fatalf("looking up registered hash: got %q, %v", got, err)
+	}
+	if got, err := r.Resolve("", "{ a }"); err != nil || got != "{ a }" {
+		t.Fatalf("plain query: got %q, %v", got, err)
+	}
+}
+
+func TestResolve_Allowlist(t *testing.T) {
+	allowlist, err := persisted.LoadAllowlist(strings.NewReader(`{"` + persisted.Hash(query) + `": "` + query + `"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &persisted.Resolver{Cache: persisted.NewCache(10), Allowlist: allowlist}
+
+	if got, err := r.Resolve(persisted.Hash(query), ""); err != nil || got != query {
+		t.Fatalf("allowlisted hash: got %q, %v", got, err)
+	}
+	if got, err := r.Resolve("", query); err != nil || got != query {
+		t.Fatalf("allowlisted query: got %q, %v", got, err)
+	}
+	other := `{ transport { taxi_availability { type } } }`
+	if _, err := r.Resolve(persisted.Hash(other), other); err != persisted.ErrNotAllowed {
+		t.Fatalf("expected ErrNotAllowed, got %v", err)
+	}
+}
+
+func TestLoadAllowlist_UpperCaseHash(t *testing.T) {
+	hash := strings.ToUpper(persisted.Hash(query))
+	allowlist, err := persisted.LoadAllowlist(strings.NewReader(`{"` + hash + `": "` + query + `"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &persisted.Resolver{Allowlist: allowlist}
+	if got, err := r.Resolve(hash, ""); err != nil || got != query {
+		t.Fatalf("upper case hash: got %q, %v", got, err)
+	}
+	if got, err := r.Resolve("", query); err != nil || got != query {
+		t.Fatalf("query of an upper case hash: got %q, %v", got, err)
+	}
+}
+
+func TestLoadAllowlist_RejectsWrongHash(t *testing.T) {
+	if _, err := persisted.LoadAllowlist(strings.NewReader(`{"abc": "` + query + `"}`)); err == nil {
+		t.Fatal("expected error for mismatched hash")
+	}
+}
+
+func TestRequestHash(t *testing.T) {
+	hash := persisted.Hash(query)
+	body := `{"extensions": {"persistedQuery": {"version": 1, "sha256Hash": "` + hash + `"}}}`
+	r := httptest.NewRequest("POST", "/graphql", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	if got := persisted.RequestHash(r); got != hash {
+		t.Fatalf("expected %v, got %q", hash, got)
+	}
+	if rest, _ := ioutil.ReadAll(r.Body); string(rest) != body {
+		t.Fatalf("expected the body to be left in place, got %q", rest)
+	}
+
+	// bodies over the limit are not read in full
+	padded := body[:len(body)-1] + `, "query": "` + strings.Repeat(" ", int(persisted.MaxBodyBytes)) + `"}`
+	r = httptest.NewRequest("POST", "/graphql", strings.NewReader(padded))
+	r.Header.Set("Content-Type", "application/json")
+	if got := persisted.RequestHash(r); got != "" {
+		t.Fatalf("expected no hash for a body over the limit, got %q", got)
+	}
+}
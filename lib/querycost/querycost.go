@@ -0,0 +1,273 @@
+package querycost
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"sort"
+	"strings"
+)
+
+// Limits on the queries the server will execute. A zero limit is not enforced.
+type Limits struct {
+	MaxDepth int
+	MaxCost  int
+}
+
+// Analyzer estimates the depth of a query and the upstream cost of executing it
+type Analyzer struct {
+	Schema graphql.Schema
+
+	// Datasets maps fields ("Type.field") to the upstream datasets their resolvers fetch
+	Datasets map[string][]string
+
+	// DateArgs are the field arguments that change which upstream URL is fetched
+	DateArgs []string
+
+	// FieldCost is the cost of each field in the query; FetchCost is the cost of each distinct upstream fetch
+	FieldCost int
+	FetchCost int
+
+	Limits Limits
+}
+
+// Analysis is the result of analysing a query
+type Analysis struct {
+	Depth   int `json:"depth"`
+	Fields  int `json:"fields"`
+	Fetches int `json:"fetches"`
+	Cost    int `json:"cost"`
+}
+
+// Analyze walks an operation of the query, following fragments, and returns its depth and cost.
+// Fetches of the same dataset with the same date arguments are counted once, since the client coalesces them.
+// Each fragment is walked once, however often it is spread, and the fields counted stop at MaxFields.
+// Queries that do not parse return an empty analysis and are left for graphql.Do to report.
+func (a *Analyzer) Analyze(query string, variables map[string]interface{}, operationName string) Analysis {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return Analysis{}
+	}
+
+	w := &walker{
+		analyzer:  a,
+		variables: variables,
+		fragments: map[string]*ast.FragmentDefinition{},
+		fetches:   map[string]bool{},
+		visiting:  map[string]bool{},
+		walked:    map[string]*fragmentAnalysis{},
+	}
+	var operation *ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch d := definition.(type) {
+		case *ast.FragmentDefinition:
+			w.fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			if operation == nil && (operationName == "" || (d.Name != nil && d.Name.Value == operationName)) {
+				operation = d
+			}
+		}
+	}
+	if operation == nil || operation.Operation != "query" {
+		return Analysis{}
+	}
+
+	depth := w.selectionSet(a.Schema.QueryType(), operation.SelectionSet, 1)
+	return Analysis{
+		Depth:   depth,
+		Fields:  w.fields,
+		Fetches: len(w.fetches),
+		Cost:    w.fields*a.FieldCost + len(w.fetches)*a.FetchCost,
+	}
+}
+
+// Check returns an error if the analysis exceeds the analyzer's limits
+func (a *Analyzer) Check(analysis Analysis) error {
+	if a.Limits.MaxDepth > 0 && analysis.Depth > a.Limits.MaxDepth {
+		return fmt.Errorf("query depth %v exceeds the limit of %v", analysis.Depth, a.Limits.MaxDepth)
+	}
+	if a.Limits.MaxCost > 0 && analysis.Cost > a.Limits.MaxCost {
+		return fmt.Errorf("query cost %v exceeds the limit of %v (%v fields, %v distinct upstream fetches); "+
+			"request fewer fields or fewer distinct dates", analysis.Cost, a.Limits.MaxCost, analysis.Fields, analysis.Fetches)
+	}
+	return nil
+}
+
+// Sum returns the combined analysis of the operations of a batch: the depth of the deepest, and the total fields,
+// fetches and cost. Fetches are summed per operation, so a batch is never estimated below what it may cost.
+func Sum(analyses ...Analysis) Analysis {
+	sum := Analysis{}
+	for _, analysis := range analyses {
+		if analysis.Depth > sum.Depth {
+			sum.Depth = analysis.Depth
+		}
+		sum.Fields += analysis.Fields
+		sum.Fetches += analysis.Fetches
+		sum.Cost += analysis.Cost
+	}
+	return sum
+}
+
+// MaxFields is the most fields an analysis counts, which is far more than any query within the limits has.
+// Fragments spread many times over can otherwise count more fields than fit in an int.
+const MaxFields = 1 << 30
+
+type walker struct {
+	analyzer  *Analyzer
+	variables map[string]interface{}
+	fragments map[string]*ast.FragmentDefinition
+	fetches   map[string]bool
+	fields    int
+
+	// fragments currently being walked, to guard against cycles
+	visiting map[string]bool
+
+	// fragments already walked, by name
+	walked map[string]*fragmentAnalysis
+}
+
+// fragmentAnalysis is what walking a fragment adds to an analysis, to be added again wherever it is spread
+type fragmentAnalysis struct {
+	// depth is the depth of the fragment's deepest field, relative to where it is spread
+	depth   int
+	fields  int
+	fetches []string
+}
+
+// selectionSet walks a selection set on a parent type and returns the depth of its deepest field
+func (w *walker) selectionSet(parent graphql.Type, set *ast.SelectionSet, depth int) int {
+	if set == nil {
+		return depth - 1
+	}
+	max := depth
+	for _, selection := range set.Selections {
+		d := depth
+		switch s := selection.(type) {
+		case *ast.Field:
+			d = w.field(parent, s, depth)
+		case *ast.InlineFragment:
+			t := parent
+			if s.TypeCondition != nil {
+				t = w.analyzer.Schema.Type(s.TypeCondition.Name.Value)
+			}
+			d = w.selectionSet(t, s.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			d = w.fragmentSpread(s.Name.Value, depth)
+		}
+		if d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+// fragmentSpread walks a fragment the first time it is spread, and adds the same fields and fetches on every spread.
+// It returns the depth of the fragment's deepest field.
+func (w *walker) fragmentSpread(name string, depth int) int {
+	fragment, ok := w.fragments[name]
+	if !ok || w.visiting[name] {
+		return depth
+	}
+	analysis, ok := w.walked[name]
+	if !ok {
+		fields, fetches := w.fields, w.fetches
+		w.fields, w.fetches = 0, map[string]bool{}
+		w.visiting[name] = true
+		d := w.selectionSet(w.analyzer.Schema.Type(fragment.TypeCondition.Name.Value), fragment.SelectionSet, depth)
+		w.visiting[name] = false
+
+		analysis = &fragmentAnalysis{depth: d - depth, fields: w.fields}
+		for fetch := range w.fetches {
+			analysis.fetches = append(analysis.fetches, fetch)
+		}
+		w.walked[name] = analysis
+		w.fields, w.fetches = fields, fetches
+	}
+
+	w.addFields(analysis.fields)
+	for _, fetch := range analysis.fetches {
+		w.fetches[fetch] = true
+	}
+	return depth + analysis.depth
+}
+
+// addFields counts fields, up to MaxFields
+func (w *walker) addFields(n int) {
+	w.fields += n
+	if w.fields > MaxFields {
+		w.fields = MaxFields
+	}
+}
+
+func (w *walker) field(parent graphql.Type, field *ast.Field, depth int) int {
+	w.addFields(1)
+
+	var definition *graphql.FieldDefinition
+	switch p := parent.(type) {
+	case *graphql.Object:
+		definition = p.Fields()[field.Name.Value]
+	case *graphql.Interface:
+		definition = p.Fields()[field.Name.Value]
+	}
+	if definition == nil {
+		// introspection fields, unknown fields (left for validation to report), or fields on unions
+		return w.selectionSet(nil, field.SelectionSet, depth+1)
+	}
+
+	key := parent.Name() + "." + field.Name.Value
+	if datasets, ok := w.analyzer.Datasets[key]; ok {
+		args := w.dateArgs(field)
+		for _, dataset := range datasets {
+			w.fetches[dataset+"?"+args] = true
+		}
+	}
+	return w.selectionSet(namedType(definition.Type), field.SelectionSet, depth+1)
+}
+
+// namedType unwraps lists and non-nulls
+func namedType(t graphql.Type) graphql.Type {
+	for {
+		switch wrapped := t.(type) {
+		case *graphql.NonNull:
+			t = wrapped.OfType
+		case *graphql.List:
+			t = wrapped.OfType
+		default:
+			return t
+		}
+	}
+}
+
+// dateArgs returns the field's date arguments, as a string that is the same for the same upstream URL
+func (w *walker) dateArgs(field *ast.Field) string {
+	args := []string{}
+	for _, arg := range field.Arguments {
+		name := arg.Name.Value
+		if !w.isDateArg(name) {
+			continue
+		}
+		var value interface{}
+		switch v := arg.Value.(type) {
+		case *ast.Variable:
+			value = w.variables[v.Name.Value]
+		default:
+			value = v.GetValue()
+		}
+		if value == nil || value == "" {
+			continue
+		}
+		args = append(args, fmt.Sprintf("%v=%v", name, value))
+	}
+	sort.Strings(args)
+	return strings.Join(args, "&")
+}
+
+func (w *walker) isDateArg(name string) bool {
+	for _, arg := range w.analyzer.DateArgs {
+		if arg == name {
+			return true
+		}
+	}
+	return false
+}
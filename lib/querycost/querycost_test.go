@@ -0,0 +1,126 @@
+package querycost_test
+
+import (
+	"fmt"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/querycost"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/schema"
+	"strings"
+	"testing"
+	"time"
+)
+
+func analyzer() *querycost.Analyzer {
+	return &querycost.Analyzer{
+		Schema:    schema.Root,
+		Datasets:  schema.UpstreamDatasets,
+		DateArgs:  schema.DateArgs,
+		FieldCost: 1,
+		FetchCost: 10,
+		Limits: querycost.Limits{
+			MaxDepth: 5,
+			MaxCost:  100,
+		},
+	}
+}
+
+func TestAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		query     string
+		variables map[string]interface{}
+		expected  querycost.Analysis
+	}{
+		{
+			// aliases of the same dataset and dates are fetched once
+			query:    `{ environment { a: psi { api_info { status } } b: psi { api_info { status } } } }`,
+			expected: querycost.Analysis{Depth: 4, Fields: 7, Fetches: 1, Cost: 17},
+		},
+		{
+			query: `query ($d: DatetimeString) {
+				environment {
+					a: psi(date_time: "2016-12-01T10:00:00") { items { timestamp } }
+					b: psi(date_time: $d) { items { timestamp } }
+					c: psi(date_time: "2016-12-01T10:00:00") { items { timestamp } }
+				}
+			}`,
+			variables: map[string]interface{}{"d": "2016-12-02T10:00:00"},
+			expected:  querycost.Analysis{Depth: 4, Fields: 10, Fetches: 2, Cost: 30},
+		},
+		{
+			// fragments are followed; weather_at fetches the same two-hour forecast as the environment field
+			query: `
+				{ weather_at(latitude: 1.35, longitude: 103.8) { region } ...Env }
+				fragment Env on RootQuery { environment { two_hour_weather_forecast { items { timestamp } } } }
+			`,
+			expected: querycost.Analysis{Depth: 4, Fields: 6, Fetches: 5, Cost: 56},
+		},
+		{
+			// fields are counted on every spread of a fragment, and its fetches once
+			query: `
+				{ environment { ...PSI } a: environment { ...PSI } }
+				fragment PSI on Environment { psi { items { timestamp } } }
+			`,
+			expected: querycost.Analysis{Depth: 4, Fields: 8, Fetches: 1, Cost: 18},
+		},
+	}
+	for i, test := range tests {
+		if analysis := analyzer().Analyze(test.query, test.variables, ""); analysis != test.expected {
+			t.Errorf("query %v: expected %+v, got %+v", i, test.expected, analysis)
+		}
+	}
+}
+
+func TestAnalyzer_AnalyzeFragmentFanOut(t *testing.T) {
+	// each fragment spreads the next ten times, for 10^20 fields in all
+	query := "{ ...F0 }\n"
+	for i := 0; i < 20; i++ {
+		query += fmt.Sprintf("fragment F%v on RootQuery { %v}\n", i, strings.Repeat(fmt.Sprintf("...F%v ", i+1), 10))
+	}
+	query += "fragment F20 on RootQuery { __typename }\n"
+
+	done := make(chan querycost.Analysis, 1)
+	go func() {
+		done <- analyzer().Analyze(query, nil, "")
+	}()
+	select {
+	case analysis := <-done:
+		expected := querycost.Analysis{Depth: 1, Fields: querycost.MaxFields, Fetches: 0, Cost: querycost.MaxFields}
+		if analysis != expected {
+			t.Errorf("expected %+v, got %+v", expected, analysis)
+		}
+		if err := analyzer().Check(analysis); err == nil {
+			t.Error("expected the fan-out to be over the cost limit")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the analysis to finish within a second")
+	}
+}
+
+func TestAnalyzer_Check(t *testing.T) {
+	a := analyzer()
+	if err := a.Check(querycost.Analysis{Depth: 5, Cost: 100}); err != nil {
+		t.Errorf("expected query at the limits to pass, got %v", err)
+	}
+	if err := a.Check(querycost.Analysis{Depth: 6, Cost: 10}); err == nil {
+		t.Error("expected query over the depth limit to fail")
+	}
+	if err := a.Check(querycost.Analysis{Depth: 1, Cost: 101}); err == nil {
+		t.Error("expected query over the cost limit to fail")
+	}
+}
+
+func TestSum(t *testing.T) {
+	sum := querycost.Sum(
+		querycost.Analysis{Depth: 4, Fields: 7, Fetches: 1, Cost: 17},
+		querycost.Analysis{Depth: 5, Fields: 10, Fetches: 2, Cost: 30},
+		querycost.Analysis{Depth: 3, Fields: 60, Fetches: 0, Cost: 60},
+	)
+	if expected := (querycost.Analysis{Depth: 5, Fields: 77, Fetches: 3, Cost: 107}); sum != expected {
+		t.Fatalf("expected %+v, got %+v", expected, sum)
+	}
+	if err := analyzer().Check(sum); err == nil {
+		t.Error("expected a batch over the cost limit to fail, although each operation is within it")
+	}
+	if sum := querycost.Sum(); sum != (querycost.Analysis{}) {
+		t.Errorf("expected an empty analysis of an empty batch, got %+v", sum)
+	}
+}
@@ -0,0 +1,213 @@
+package resultcache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"log"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Key returns the cache key of an operation. The query is parsed and printed again, so queries that only differ in
+// whitespace, commas or comments share a key.
+func Key(query string, variables map[string]interface{}, operationName string) (string, error) {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return "", err
+	}
+	if len(variables) == 0 {
+		variables = map[string]interface{}{}
+	}
+	// map keys are sorted by encoding/json
+	vars, err := json.Marshal(variables)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%v\x00%v\x00%s", printer.Print(doc), operationName, vars)))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Cache keeps the most recently used GraphQL results in memory until the data they were built from goes stale
+type Cache struct {
+	// MaxEntries is the maximum number of results kept. The cache is disabled if it is 0.
+	MaxEntries int
+
+	lock    sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+
+	// latest upstream timestamp seen by the poller, by dataset path
+	timestamps map[string]string
+
+	// generations counts the invalidations of each dataset path
+	generations Generation
+}
+
+// Generation is how many times each dataset path had been invalidated when it was taken
+type Generation map[string]uint64
+
+type cacheEntry struct {
+	key     string
+	result  *graphql.Result
+	expires time.Time
+	paths   map[string]bool
+}
+
+// New returns a new Cache holding up to maxEntries results
+func New(maxEntries int) *Cache {
+	return &Cache{
+		MaxEntries:  maxEntries,
+		entries:     map[string]*list.Element{},
+		lru:         list.New(),
+		timestamps:  map[string]string{},
+		generations: Generation{},
+	}
+}
+
+// Get returns a cached result and how long it stays fresh
+func (c *Cache) Get(key string) (*graphql.Result, time.Duration, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, 0, false
+	}
+	entry := el.Value.(*cacheEntry)
+	ttl := entry.expires.Sub(time.Now())
+	if ttl <= 0 {
+		c.remove(el)
+		return nil, 0, false
+	}
+	c.lru.MoveToFront(el)
+	return entry.result, ttl, true
+}
+
+// Generation returns the current generation of every dataset path, to be taken before executing a query and given
+// to Put with its result
+func (c *Cache) Generation() Generation {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	generation := Generation{}
+	for path, n := range c.generations {
+		generation[path] = n
+	}
+	return generation
+}
+
+// Put caches a result for ttl, or until Invalidate is called for one of the upstream URLs it was built from.
+// The result is not cached if one of those was invalidated since generation was taken, as it may have been built
+// from data that was already stale.
+func (c *Cache) Put(key string, result *graphql.Result, ttl time.Duration, urls []string, generation Generation) {
+	if c.MaxEntries <= 0 || ttl <= 0 {
+		return
+	}
+	entry := &cacheEntry{
+		key:     key,
+		result:  result,
+		expires: time.Now().Add(ttl),
+		paths:   map[string]bool{},
+	}
+	for _, rawurl := range urls {
+		if u, err := url.Parse(rawurl); err == nil {
+			entry.paths[u.Path] = true
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for path := range entry.paths {
+		if c.generations[path] != generation[path] {
+			return
+		}
+	}
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.MaxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+// Invalidate drops every cached result built from the dataset at the given URL path, and returns how many there were
+func (c *Cache) Invalidate(path string) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.generations[path]++
+	n := 0
+	for _, el := range c.entries {
+		if el.Value.(*cacheEntry).paths[path] {
+			c.remove(el)
+			n++
+		}
+	}
+	return n
+}
+
+// Len returns the number of cached results
+func (c *Cache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Len()
+}
+
+func (c *Cache) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry).key)
+}
+
+// latest is the part of every data.gov.sg response that changes when new data is published.
+// APIInfo is kept so the poller can report each endpoint's status.
+type latest struct {
+	APIInfo datagovsg.APIInfo `json:"api_info"`
+	Items   []struct {
+		Timestamp       string `json:"timestamp"`
+		UpdateTimestamp string `json:"update_timestamp"`
+	} `json:"items"`
+}
+
+// Watch registers the cache with a poller, to invalidate results as soon as a dataset in datagovsg.DatasetTTLs
+// publishes new data
+func (c *Cache) Watch(p *datagovsg.Poller, interval time.Duration) {
+	for path := range datagovsg.DatasetTTLs {
+		path := path
+		p.Watch(
+			"https://api.data.gov.sg"+path,
+			interval,
+			func() interface{} { return &latest{} },
+			func(res datagovsg.ClientResult) {
+				if res.Err != nil {
+					log.Println("resultcache", path, res.Err)
+					return
+				}
+				resp, _ := res.Body.(*latest)
+				timestamp := ""
+				for _, item := range resp.Items {
+					timestamp += item.Timestamp + item.UpdateTimestamp
+				}
+				c.seen(path, timestamp)
+			},
+		)
+	}
+}
+
+// seen records the latest upstream timestamp of a dataset, invalidating its results if it changed
+func (c *Cache) seen(path string, timestamp string) {
+	c.lock.Lock()
+	previous, ok := c.timestamps[path]
+	c.timestamps[path] = timestamp
+	c.lock.Unlock()
+	if ok && previous != timestamp {
+		if n := c.Invalidate(path); n > 0 {
+			log.Println("resultcache", path, "updated, invalidated", n, "results")
+		}
+	}
+}
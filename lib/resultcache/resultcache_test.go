@@ -0,0 +1,86 @@
+package resultcache_test
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/resultcache"
+	"testing"
+	"time"
+)
+
+func TestKey_Normalised(t *testing.T) {
+	a, err := resultcache.Key("{ environment { psi { items { timestamp } } } }", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := resultcache.Key(`
+		# latest PSI
+		{
+			environment { psi { items { timestamp }, }, }
+		}`, map[string]interface{}{}, "")
+	if a != b {
+		t.Fatal("expected queries differing only in whitespace, commas and comments to share a key")
+	}
+
+	c, _ := resultcache.Key("query Q($d: String) { environment { psi(date: $d) { items { timestamp } } } }",
+		map[string]interface{}{"d": "2016-12-01"}, "")
+	d, _ := resultcache.Key("query Q($d: String) { environment { psi(date: $d) { items { timestamp } } } }",
+		map[string]interface{}{"d": "2016-12-02"}, "")
+	if c == d {
+		t.Fatal("expected different variables to give different keys")
+	}
+
+	if _, err := resultcache.Key("{", nil, ""); err == nil {
+		t.Fatal("expected error for invalid query")
+	}
+}
+
+func TestCache_EvictAndInvalidate(t *testing.T) {
+	c := resultcache.New(2)
+	result := &graphql.Result{}
+	c.Put("psi", result, time.Minute, []string{"https://api.data.gov.sg/v1/environment/psi?date=2016-12-01"}, c.Generation())
+	c.Put("taxis", result, time.Minute, []string{"https://api.data.gov.sg/v1/transport/taxi-availability"}, c.Generation())
+	c.Put("expired", result, 0, nil, c.Generation())
+	if c.Len() != 2 {
+		t.Fatalf("expected 2 results, got %v", c.Len())
+	}
+
+	c.Get("psi")
+	c.Put("uv", result, time.Minute, []string{"https://api.data.gov.sg/v1/environment/uv-index"}, c.Generation())
+	if _, _, ok := c.Get("taxis"); ok {
+		t.Fatal("expected least recently used result to be evicted")
+	}
+
+	if n := c.Invalidate("/v1/environment/psi"); n != 1 {
+		t.Fatalf("expected 1 result to be invalidated, got %v", n)
+	}
+	if _, _, ok := c.Get("psi"); ok {
+		t.Fatal("expected invalidated result to be gone")
+	}
+	if _, ttl, ok := c.Get("uv"); !ok || ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("expected uv result to be cached for up to a minute, got %v, %v", ttl, ok)
+	}
+}
+
+func TestCache_PutAfterInvalidate(t *testing.T) {
+	c := resultcache.New(10)
+	psi := []string{"https://api.data.gov.sg/v1/environment/psi"}
+
+	// the dataset is updated while the query runs
+	generation := c.Generation()
+	c.Invalidate("/v1/environment/psi")
+	c.Put("psi", &graphql.Result{}, time.Minute, psi, generation)
+	if _, _, ok := c.Get("psi"); ok {
+		t.Fatal("expected a result built before an invalidation not to be cached")
+	}
+
+	// other datasets are unaffected
+	c.Put("uv", &graphql.Result{}, time.Minute, []string{"https://api.data.gov.sg/v1/environment/uv-index"}, generation)
+	if _, _, ok := c.Get("uv"); !ok {
+		t.Fatal("expected a result of another dataset to be cached")
+	}
+
+	c.Put("psi", &graphql.Result{}, time.Minute, psi, c.Generation())
+	if _, _, ok := c.Get("psi"); !ok {
+		t.Fatal("expected a result built after the invalidation to be cached")
+	}
+}
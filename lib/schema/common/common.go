@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/language/ast"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/geojson"
 	"regexp"
 )
 
@@ -16,11 +18,17 @@ var APIInfoStatusObject *graphql.Object
 var DateTimeRangeObject *graphql.Object
 var LocationObject *graphql.Object
 var AreaObject *graphql.Object
+var RegionEnum *graphql.Enum
+var SpeedUnitEnum *graphql.Enum
 var SpeedObject *graphql.Object
 var RelativeHumidityObject *graphql.Object
+var TemperatureUnitEnum *graphql.Enum
 var TemperatureObject *graphql.Object
 var WindObject *graphql.Object
 
+// Inputs
+var LocationInputObject *graphql.InputObject
+
 func init() {
 
 	dateRegexp, err := regexp.Compile("((19|20)\\d\\d)-(0?[1-9]|1[012])-(0?[1-9]|[12][0-9]|3[01])")
@@ -128,17 +136,81 @@ func init() {
 			"label_location": &graphql.Field{
 				Type: graphql.NewNonNull(LocationObject),
 			},
+			"region_boundary": &graphql.Field{
+				Description: "Boundary of the region (north, south, east, west, central) or planning area " +
+					"this area refers to, as a GeoJSON Feature",
+				Type: geojson.GeoJSONInterface,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					area, _ := p.Source.(datagovsg.Area)
+					if boundary := geojson.DefaultBoundaries.Region(area.Name); boundary != nil {
+						return boundary.Feature(), nil
+					}
+					if boundary := geojson.DefaultBoundaries.PlanningArea(area.Name); boundary != nil {
+						return boundary.Feature(), nil
+					}
+					return nil, nil
+				},
+			},
+		},
+	})
+	RegionEnum = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "Region",
+		Description: "Regions used by the NEA forecasts and readings",
+		Values: graphql.EnumValueConfigMap{
+			"NATIONAL": &graphql.EnumValueConfig{
+				Value:       "national",
+				Description: "Singapore as a whole",
+			},
+			"NORTH": &graphql.EnumValueConfig{
+				Value: "north",
+			},
+			"SOUTH": &graphql.EnumValueConfig{
+				Value: "south",
+			},
+			"EAST": &graphql.EnumValueConfig{
+				Value: "east",
+			},
+			"WEST": &graphql.EnumValueConfig{
+				Value: "west",
+			},
+			"CENTRAL": &graphql.EnumValueConfig{
+				Value: "central",
+			},
+		},
+	})
+	SpeedUnitEnum = graphql.NewEnum(graphql.EnumConfig{
+		Name: "SpeedUnit",
+		Values: graphql.EnumValueConfigMap{
+			"KMH": &graphql.EnumValueConfig{
+				Value:       datagovsg.KMH,
+				Description: "Kilometres per hour",
+			},
+			"MS": &graphql.EnumValueConfig{
+				Value:       datagovsg.MS,
+				Description: "Metres per second",
+			},
+			"MPH": &graphql.EnumValueConfig{
+				Value:       datagovsg.MPH,
+				Description: "Miles per hour",
+			},
+			"KNOTS": &graphql.EnumValueConfig{
+				Value: datagovsg.Knots,
+			},
 		},
 	})
 	SpeedObject = graphql.NewObject(graphql.ObjectConfig{
 		Name: "Speed",
 		Fields: graphql.Fields{
 			"high": &graphql.Field{
-				Type: graphql.Int,
+				Description: "In km/h",
+				Type:        graphql.Int,
 			},
 			"low": &graphql.Field{
-				Type: graphql.Int,
+				Description: "In km/h",
+				Type:        graphql.Int,
 			},
+			"high_in": speedField(func(s datagovsg.Speed) int { return s.High }),
+			"low_in":  speedField(func(s datagovsg.Speed) int { return s.Low }),
 		},
 	})
 	RelativeHumidityObject = graphql.NewObject(graphql.ObjectConfig{
@@ -152,15 +224,33 @@ func init() {
 			},
 		},
 	})
+	TemperatureUnitEnum = graphql.NewEnum(graphql.EnumConfig{
+		Name: "TemperatureUnit",
+		Values: graphql.EnumValueConfigMap{
+			"CELSIUS": &graphql.EnumValueConfig{
+				Value: datagovsg.Celsius,
+			},
+			"FAHRENHEIT": &graphql.EnumValueConfig{
+				Value: datagovsg.Fahrenheit,
+			},
+			"KELVIN": &graphql.EnumValueConfig{
+				Value: datagovsg.Kelvin,
+			},
+		},
+	})
 	TemperatureObject = graphql.NewObject(graphql.ObjectConfig{
 		Name: "Temperature",
 		Fields: graphql.Fields{
 			"high": &graphql.Field{
-				Type: graphql.Int,
+				Description: "In degrees Celsius",
+				Type:        graphql.Int,
 			},
 			"low": &graphql.Field{
-				Type: graphql.Int,
+				Description: "In degrees Celsius",
+				Type:        graphql.Int,
 			},
+			"high_in": temperatureField(func(t datagovsg.Temperature) int { return t.High }),
+			"low_in":  temperatureField(func(t datagovsg.Temperature) int { return t.Low }),
 		},
 	})
 	WindObject = graphql.NewObject(graphql.ObjectConfig{
@@ -172,7 +262,92 @@ func init() {
 			"direction": &graphql.Field{
 				Type: graphql.NewNonNull(graphql.String),
 			},
+			"direction_degrees": &graphql.Field{
+				Description: "Wind direction in degrees clockwise from north, or null if it is not a compass point",
+				Type:        graphql.Float,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					wind, _ := p.Source.(datagovsg.Wind)
+					if degrees, ok := wind.DirectionDegrees(); ok {
+						return degrees, nil
+					}
+					return nil, nil
+				},
+			},
 		},
 	})
 
+	// Inputs
+	LocationInputObject = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "LocationInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"longitude": &graphql.InputObjectFieldConfig{
+				Type: graphql.NewNonNull(graphql.Float),
+			},
+			"latitude": &graphql.InputObjectFieldConfig{
+				Type: graphql.NewNonNull(graphql.Float),
+			},
+		},
+	})
+
+}
+
+// LocationFromInput converts a LocationInput argument value into a datagovsg.Location
+func LocationFromInput(value interface{}) datagovsg.Location {
+	input, _ := value.(map[string]interface{})
+	longitude, _ := input["longitude"].(float64)
+	latitude, _ := input["latitude"].(float64)
+	return datagovsg.Location{
+		Longitude: longitude,
+		Latitude:  latitude,
+	}
+}
+
+// speedField resolves a wind speed (reported in km/h) in the requested unit
+func speedField(get func(datagovsg.Speed) int) *graphql.Field {
+	return &graphql.Field{
+		Description: "In the given unit (km/h by default)",
+		Type:        graphql.Float,
+		Args: graphql.FieldConfigArgument{
+			"unit": &graphql.ArgumentConfig{
+				Type:         SpeedUnitEnum,
+				DefaultValue: datagovsg.KMH,
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			speed, _ := p.Source.(datagovsg.Speed)
+			unit, _ := p.Args["unit"].(string)
+			return datagovsg.ConvertSpeed(float64(get(speed)), unit), nil
+		},
+	}
+}
+
+// temperatureField resolves a temperature (reported in degrees Celsius) in the requested unit
+func temperatureField(get func(datagovsg.Temperature) int) *graphql.Field {
+	return &graphql.Field{
+		Description: "In the given unit (degrees Celsius by default)",
+		Type:        graphql.Float,
+		Args: graphql.FieldConfigArgument{
+			"unit": &graphql.ArgumentConfig{
+				Type:         TemperatureUnitEnum,
+				DefaultValue: datagovsg.Celsius,
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			temperature, _ := p.Source.(datagovsg.Temperature)
+			unit, _ := p.Args["unit"].(string)
+			return datagovsg.ConvertTemperature(float64(get(temperature)), unit), nil
+		},
+	}
+}
+
+// RegionsFromArgs returns the region names of a [Region] argument
+func RegionsFromArgs(value interface{}) []string {
+	regions := []string{}
+	values, _ := value.([]interface{})
+	for _, v := range values {
+		if region, ok := v.(string); ok {
+			regions = append(regions, region)
+		}
+	}
+	return regions
 }
@@ -0,0 +1,34 @@
+package schema
+
+// UpstreamDatasets maps the fields whose resolvers call data.gov.sg to the datasets they fetch.
+// It is used to estimate the upstream cost of a query before executing it, so it must be kept in step
+// with the resolvers.
+var UpstreamDatasets = map[string][]string{
+	"RootQuery.weather_at": {
+		"environment/2-hour-weather-forecast",
+		"environment/24-hour-weather-forecast",
+		"environment/psi",
+		"environment/pm25",
+		"environment/uv-index",
+	},
+
+	"Environment.two_hour_weather_forecast":         {"environment/2-hour-weather-forecast"},
+	"Environment.twenty_four_hour_weather_forecast": {"environment/24-hour-weather-forecast"},
+	"Environment.four_day_weather_forecast":         {"environment/4-day-weather-forecast"},
+	"Environment.pm25":                              {"environment/pm25"},
+	"Environment.psi":                               {"environment/psi"},
+	"Environment.uv_index":                          {"environment/uv-index"},
+	"Environment.forecast_timeline": {
+		"environment/2-hour-weather-forecast",
+		"environment/24-hour-weather-forecast",
+		"environment/4-day-weather-forecast",
+	},
+
+	"Transport.taxi_availability": {"transport/taxi-availability"},
+	"Transport.traffic_images":    {"transport/traffic-images"},
+	"Transport.camera":            {"transport/traffic-images"},
+	"Transport.cameras":           {"transport/traffic-images"},
+}
+
+// DateArgs are the arguments that are passed on to data.gov.sg, so change which URL is fetched
+var DateArgs = []string{"date_time", "date"}
@@ -38,13 +38,9 @@ func RootObject() *graphql.Object {
 					dateTime, _ := p.Args["date_time"].(string)
 					date, _ := p.Args["date"].(string)
 
-					v, _ := query.Values(datagovsg.TwoHourWeatherForecastOptions{
-						DateTime: dateTime,
-						Date:     date,
-					})
-
-					ch := c.Get(
-						fmt.Sprintf("https://api.data.gov.sg/v1/environment/2-hour-weather-forecast?%v", v.Encode()),
+					ch := c.GetContext(
+						p.Context,
+						twoHourWeatherForecastURL(dateTime, date),
 						&datagovsg.TwoHourWeatherForecastResult{},
 					)
 					res := <-ch
@@ -73,13 +69,9 @@ func RootObject() *graphql.Object {
 					dateTime, _ := p.Args["date_time"].(string)
 					date, _ := p.Args["date"].(string)
 
-					v, _ := query.Values(datagovsg.TwentyFourHourWeatherForecastOptions{
-						DateTime: dateTime,
-						Date:     date,
-					})
-
-					ch := c.Get(
-						fmt.Sprintf("https://api.data.gov.sg/v1/environment/24-hour-weather-forecast?%v", v.Encode()),
+					ch := c.GetContext(
+						p.Context,
+						twentyFourHourWeatherForecastURL(dateTime, date),
 						&datagovsg.TwentyFourHourWeatherForecastResult{},
 					)
 					res := <-ch
@@ -108,13 +100,9 @@ func RootObject() *graphql.Object {
 					dateTime, _ := p.Args["date_time"].(string)
 					date, _ := p.Args["date"].(string)
 
-					v, _ := query.Values(datagovsg.FourDayWeatherForecastOptions{
-						DateTime: dateTime,
-						Date:     date,
-					})
-
-					ch := c.Get(
-						fmt.Sprintf("https://api.data.gov.sg/v1/environment/4-day-weather-forecast?%v", v.Encode()),
+					ch := c.GetContext(
+						p.Context,
+						fourDayWeatherForecastURL(dateTime, date),
 						&datagovsg.FourDayWeatherForecastResult{},
 					)
 					res := <-ch
@@ -125,6 +113,7 @@ func RootObject() *graphql.Object {
 					return resp.ToGraphQL(), nil
 				},
 			},
+			"forecast_timeline": ForecastTimelineField(),
 			"pm25": &graphql.Field{
 				Name: "PM25 Readings",
 				Type: graphql.NewNonNull(pm25ReadingsResultObject),
@@ -143,13 +132,9 @@ func RootObject() *graphql.Object {
 					dateTime, _ := p.Args["date_time"].(string)
 					date, _ := p.Args["date"].(string)
 
-					v, _ := query.Values(datagovsg.PM25ReadingsOptions{
-						DateTime: dateTime,
-						Date:     date,
-					})
-
-					ch := c.Get(
-						fmt.Sprintf("https://api.data.gov.sg/v1/environment/pm25?%v", v.Encode()),
+					ch := c.GetContext(
+						p.Context,
+						pm25URL(dateTime, date),
 						&datagovsg.PM25ReadingsResult{},
 					)
 					res := <-ch
@@ -178,13 +163,9 @@ func RootObject() *graphql.Object {
 					dateTime, _ := p.Args["date_time"].(string)
 					date, _ := p.Args["date"].(string)
 
-					v, _ := query.Values(datagovsg.PSIReadingsOptions{
-						DateTime: dateTime,
-						Date:     date,
-					})
-
-					ch := c.Get(
-						fmt.Sprintf("https://api.data.gov.sg/v1/environment/psi?%v", v.Encode()),
+					ch := c.GetContext(
+						p.Context,
+						psiURL(dateTime, date),
 						&datagovsg.PSIReadingsResult{},
 					)
 					res := <-ch
@@ -213,13 +194,9 @@ func RootObject() *graphql.Object {
 					dateTime, _ := p.Args["date_time"].(string)
 					date, _ := p.Args["date"].(string)
 
-					v, _ := query.Values(datagovsg.UVIndexOptions{
-						DateTime: dateTime,
-						Date:     date,
-					})
-
-					ch := c.Get(
-						fmt.Sprintf("https://api.data.gov.sg/v1/environment/uv-index?%v", v.Encode()),
+					ch := c.GetContext(
+						p.Context,
+						uvIndexURL(dateTime, date),
 						&datagovsg.UVIndexReadingsResult{},
 					)
 					res := <-ch
@@ -234,3 +211,51 @@ func RootObject() *graphql.Object {
 	})
 	return environmentObject
 }
+
+func twoHourWeatherForecastURL(dateTime string, date string) string {
+	v, _ := query.Values(datagovsg.TwoHourWeatherForecastOptions{
+		DateTime: dateTime,
+		Date:     date,
+	})
+	return fmt.Sprintf("https://api.data.gov.sg/v1/environment/2-hour-weather-forecast?%v", v.Encode())
+}
+
+func twentyFourHourWeatherForecastURL(dateTime string, date string) string {
+	v, _ := query.Values(datagovsg.TwentyFourHourWeatherForecastOptions{
+		DateTime: dateTime,
+		Date:     date,
+	})
+	return fmt.Sprintf("https://api.data.gov.sg/v1/environment/24-hour-weather-forecast?%v", v.Encode())
+}
+
+func fourDayWeatherForecastURL(dateTime string, date string) string {
+	v, _ := query.Values(datagovsg.FourDayWeatherForecastOptions{
+		DateTime: dateTime,
+		Date:     date,
+	})
+	return fmt.Sprintf("https://api.data.gov.sg/v1/environment/4-day-weather-forecast?%v", v.Encode())
+}
+
+func pm25URL(dateTime string, date string) string {
+	v, _ := query.Values(datagovsg.PM25ReadingsOptions{
+		DateTime: dateTime,
+		Date:     date,
+	})
+	return fmt.Sprintf("https://api.data.gov.sg/v1/environment/pm25?%v", v.Encode())
+}
+
+func psiURL(dateTime string, date string) string {
+	v, _ := query.Values(datagovsg.PSIReadingsOptions{
+		DateTime: dateTime,
+		Date:     date,
+	})
+	return fmt.Sprintf("https://api.data.gov.sg/v1/environment/psi?%v", v.Encode())
+}
+
+func uvIndexURL(dateTime string, date string) string {
+	v, _ := query.Values(datagovsg.UVIndexOptions{
+		DateTime: dateTime,
+		Date:     date,
+	})
+	return fmt.Sprintf("https://api.data.gov.sg/v1/environment/uv-index?%v", v.Encode())
+}
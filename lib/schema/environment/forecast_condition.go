@@ -0,0 +1,170 @@
+package environment
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+)
+
+var forecastConditionEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "ForecastCondition",
+	Description: "Normalised weather forecast condition. Forecasts that cannot be recognised are UNKNOWN.",
+	Values: graphql.EnumValueConfigMap{
+		"FAIR": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionFair),
+		},
+		"FAIR_AND_WARM": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionFairAndWarm),
+		},
+		"SUNNY": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionSunny),
+		},
+		"PARTLY_CLOUDY": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionPartlyCloudy),
+		},
+		"CLOUDY": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionCloudy),
+		},
+		"OVERCAST": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionOvercast),
+		},
+		"SLIGHTLY_HAZY": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionSlightlyHazy),
+		},
+		"HAZY": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionHazy),
+		},
+		"MIST": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionMist),
+		},
+		"FOG": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionFog),
+		},
+		"DRIZZLE": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionDrizzle),
+		},
+		"LIGHT_RAIN": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionLightRain),
+		},
+		"MODERATE_RAIN": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionModerateRain),
+		},
+		"HEAVY_RAIN": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionHeavyRain),
+		},
+		"PASSING_SHOWERS": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionPassingShowers),
+		},
+		"LIGHT_SHOWERS": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionLightShowers),
+		},
+		"SHOWERS": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionShowers),
+		},
+		"HEAVY_SHOWERS": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionHeavyShowers),
+		},
+		"THUNDERY_SHOWERS": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionThunderyShowers),
+		},
+		"HEAVY_THUNDERY_SHOWERS": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionHeavyThunderyShowers),
+		},
+		"HEAVY_THUNDERY_SHOWERS_WITH_GUSTY_WINDS": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionHeavyThunderyShowersWithGustyWinds),
+		},
+		"WINDY": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionWindy),
+		},
+		"WINDY_CLOUDY": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionWindyCloudy),
+		},
+		"WINDY_FAIR": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionWindyFair),
+		},
+		"WINDY_RAIN": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionWindyRain),
+		},
+		"WINDY_SHOWERS": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionWindyShowers),
+		},
+		"STRONG_WINDS": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionStrongWinds),
+		},
+		"STRONG_WINDS_RAIN": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionStrongWindsRain),
+		},
+		"STRONG_WINDS_SHOWERS": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionStrongWindsShowers),
+		},
+		"SNOW": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionSnow),
+		},
+		"SANDSTORM": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionSandstorm),
+		},
+		"UNKNOWN": &graphql.EnumValueConfig{
+			Value: string(datagovsg.ConditionUnknown),
+		},
+	},
+})
+
+var forecastVariantEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "ForecastVariant",
+	Description: "Day or night variant of a forecast condition",
+	Values: graphql.EnumValueConfigMap{
+		"DAY": &graphql.EnumValueConfig{
+			Value: datagovsg.VariantDay,
+		},
+		"NIGHT": &graphql.EnumValueConfig{
+			Value: datagovsg.VariantNight,
+		},
+	},
+})
+
+// withForecastCondition adds condition, variant and icon fields to a forecast's fields, given a function returning
+// its raw forecast string
+func withForecastCondition(fields graphql.Fields, forecast func(p graphql.ResolveParams) string) graphql.Fields {
+	conditionFields := graphql.Fields{
+		"condition": &graphql.Field{
+			Type: graphql.NewNonNull(forecastConditionEnum),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return string(datagovsg.ParseForecastCondition(forecast(p)).Condition), nil
+			},
+		},
+		"variant": &graphql.Field{
+			Description: "Day or night variant of the condition, if NEA forecasts it differently by day and night",
+			Type:        forecastVariantEnum,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if v := datagovsg.ParseForecastCondition(forecast(p)).Variant; v != "" {
+					return v, nil
+				}
+				return nil, nil
+			},
+		},
+		"icon": &graphql.Field{
+			Description: "NEA's abbreviation for the forecast (e.g. PC for Partly Cloudy (Day)), usable as an icon code",
+			Type:        graphql.String,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if icon := datagovsg.ParseForecastCondition(forecast(p)).Icon; icon != "" {
+					return icon, nil
+				}
+				return nil, nil
+			},
+		},
+	}
+	for name, field := range conditionFields {
+		fields[name] = field
+	}
+	return fields
+}
+
+// regionConditionField resolves the condition of one region of a RegionWeatherForecast
+func regionConditionField(region string) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewNonNull(forecastConditionEnum),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			forecast, _ := p.Source.(datagovsg.RegionWeatherForecast)
+			return string(datagovsg.ParseForecastCondition(forecast.ByRegion(region)).Condition), nil
+		},
+	}
+}
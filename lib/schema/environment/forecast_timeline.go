@@ -0,0 +1,123 @@
+package environment
+
+import (
+	"errors"
+	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/common"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/geojson"
+)
+
+var forecastSourceEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "ForecastSource",
+	Description: "Forecast feed a timeline slot comes from",
+	Values: graphql.EnumValueConfigMap{
+		"TWO_HOUR": &graphql.EnumValueConfig{
+			Value: datagovsg.SourceTwoHour,
+		},
+		"TWENTY_FOUR_HOUR": &graphql.EnumValueConfig{
+			Value: datagovsg.SourceTwentyFourHour,
+		},
+		"FOUR_DAY": &graphql.EnumValueConfig{
+			Value: datagovsg.SourceFourDay,
+		},
+	},
+})
+
+var forecastSlotObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ForecastSlot",
+	Fields: withForecastCondition(graphql.Fields{
+		"source": &graphql.Field{
+			Type: graphql.NewNonNull(forecastSourceEnum),
+		},
+		"valid_period": &graphql.Field{
+			Type: graphql.NewNonNull(common.DateTimeRangeObject),
+		},
+		"forecast": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"temperature": &graphql.Field{
+			Description: "Forecast temperature range. For TWENTY_FOUR_HOUR slots this is the range for the whole 24 hours. " +
+				"Null for TWO_HOUR slots.",
+			Type: common.TemperatureObject,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				slot, _ := p.Source.(datagovsg.ForecastSlot)
+				if slot.Temperature == nil {
+					return nil, nil
+				}
+				return *slot.Temperature, nil
+			},
+		},
+	}, func(p graphql.ResolveParams) string {
+		slot, _ := p.Source.(datagovsg.ForecastSlot)
+		return slot.Forecast
+	}),
+})
+
+// ForecastTimelineField returns the field that merges the two-hour, twenty-four hour and four-day weather
+// forecasts into a single timeline. The three datasets are requested at once through the shared client.
+func ForecastTimelineField() *graphql.Field {
+	return &graphql.Field{
+		Name: "Forecast Timeline",
+		Description: "Two-hour, twenty-four hour and four-day forecasts merged into chronologically ordered, " +
+			"non-overlapping slots. Where feeds overlap, the finer grained one is used. " +
+			"If only area is given, region is the region containing the area. If neither is given, the national forecast is used.",
+		Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(forecastSlotObject))),
+		Args: graphql.FieldConfigArgument{
+			"area": &graphql.ArgumentConfig{
+				Description: "Two-hour weather forecast area, e.g. Bishan",
+				Type:        graphql.String,
+			},
+			"region": &graphql.ArgumentConfig{
+				Type: common.RegionEnum,
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+
+			c := datagovsg.GetClientFromContext(p.Context)
+
+			area, _ := p.Args["area"].(string)
+			region, _ := p.Args["region"].(string)
+
+			// fire off all requests before waiting on any of them
+			twoHourCh := c.GetContext(p.Context, twoHourWeatherForecastURL("", ""), &datagovsg.TwoHourWeatherForecastResult{})
+			twentyFourHourCh := c.GetContext(p.Context, twentyFourHourWeatherForecastURL("", ""), &datagovsg.TwentyFourHourWeatherForecastResult{})
+			fourDayCh := c.GetContext(p.Context, fourDayWeatherForecastURL("", ""), &datagovsg.FourDayWeatherForecastResult{})
+
+			src := datagovsg.ForecastTimelineSources{}
+			var err error
+			for _, ch := range []chan datagovsg.ClientResult{twoHourCh, twentyFourHourCh, fourDayCh} {
+				res := <-ch
+				if res.Err != nil {
+					// keep draining the remaining channels so that no broadcast is left blocked
+					err = res.Err
+					continue
+				}
+				switch body := res.Body.(type) {
+				case *datagovsg.TwoHourWeatherForecastResult:
+					src.TwoHourWeatherForecast = body
+				case *datagovsg.TwentyFourHourWeatherForecastResult:
+					src.TwentyFourHourWeatherForecast = body
+				case *datagovsg.FourDayWeatherForecastResult:
+					src.FourDayWeatherForecast = body
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if area != "" {
+				a := src.TwoHourWeatherForecast.AreaByName(area)
+				if a.Name == "" {
+					return nil, errors.New("unknown area: " + area)
+				}
+				if region == "" {
+					if boundary := geojson.DefaultBoundaries.RegionAt(a.LabelLocation.Longitude, a.LabelLocation.Latitude); boundary != nil {
+						region = boundary.Name
+					}
+				}
+			}
+			return datagovsg.NewForecastTimeline(area, region, src), nil
+		},
+	}
+}
@@ -2,12 +2,13 @@ package environment
 
 import (
 	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
 	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/common"
 )
 
 var fourDayWeatherForecastObject = graphql.NewObject(graphql.ObjectConfig{
 	Name: "FourDayWeatherForecast",
-	Fields: graphql.Fields{
+	Fields: withForecastCondition(graphql.Fields{
 		"timestamp": &graphql.Field{
 			Type: graphql.NewNonNull(graphql.String),
 		},
@@ -26,7 +27,10 @@ var fourDayWeatherForecastObject = graphql.NewObject(graphql.ObjectConfig{
 		"temperature": &graphql.Field{
 			Type: graphql.NewNonNull(common.TemperatureObject),
 		},
-	},
+	}, func(p graphql.ResolveParams) string {
+		forecast, _ := p.Source.(datagovsg.FourDayWeatherForecast)
+		return forecast.Forecast
+	}),
 })
 
 var fourDayWeatherForecastResultItemObject = graphql.NewObject(graphql.ObjectConfig{
@@ -0,0 +1,106 @@
+package environment
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+)
+
+var healthBandEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "HealthBand",
+	Description: "NEA air quality descriptor band: a PSI band, or a 1-hour PM2.5 band",
+	Values: graphql.EnumValueConfigMap{
+		"GOOD": &graphql.EnumValueConfig{
+			Value:       string(datagovsg.HealthBandGood),
+			Description: "PSI 0-50",
+		},
+		"MODERATE": &graphql.EnumValueConfig{
+			Value:       string(datagovsg.HealthBandModerate),
+			Description: "PSI 51-100",
+		},
+		"UNHEALTHY": &graphql.EnumValueConfig{
+			Value:       string(datagovsg.HealthBandUnhealthy),
+			Description: "PSI 101-200",
+		},
+		"VERY_UNHEALTHY": &graphql.EnumValueConfig{
+			Value:       string(datagovsg.HealthBandVeryUnhealthy),
+			Description: "PSI 201-300",
+		},
+		"HAZARDOUS": &graphql.EnumValueConfig{
+			Value:       string(datagovsg.HealthBandHazardous),
+			Description: "PSI above 300",
+		},
+		"NORMAL": &graphql.EnumValueConfig{
+			Value:       string(datagovsg.HealthBandNormal),
+			Description: "1-hour PM2.5 0-55 µg/m³ (band I)",
+		},
+		"ELEVATED": &graphql.EnumValueConfig{
+			Value:       string(datagovsg.HealthBandElevated),
+			Description: "1-hour PM2.5 56-150 µg/m³ (band II)",
+		},
+		"HIGH": &graphql.EnumValueConfig{
+			Value:       string(datagovsg.HealthBandHigh),
+			Description: "1-hour PM2.5 151-250 µg/m³ (band III)",
+		},
+		"VERY_HIGH": &graphql.EnumValueConfig{
+			Value:       string(datagovsg.HealthBandVeryHigh),
+			Description: "1-hour PM2.5 251 µg/m³ and above (band IV)",
+		},
+	},
+})
+
+var healthAdvisoryObject = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "HealthAdvisory",
+	Description: "NEA health advisory",
+	Fields: graphql.Fields{
+		"healthy_persons": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"vulnerable": &graphql.Field{
+			Description: "Elderly, pregnant women and children",
+			Type:        graphql.NewNonNull(graphql.String),
+		},
+		"chronic_conditions": &graphql.Field{
+			Description: "Persons with chronic lung disease or heart disease",
+			Type:        graphql.NewNonNull(graphql.String),
+		},
+	},
+})
+
+// withHealthBand adds band, descriptor and advisory fields to a reading's fields, given a function returning its band
+func withHealthBand(fields graphql.Fields, band func(p graphql.ResolveParams) datagovsg.HealthBand) graphql.Fields {
+	bandFields := graphql.Fields{
+		"band": &graphql.Field{
+			Type: healthBandEnum,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if b := band(p); b != "" {
+					return string(b), nil
+				}
+				return nil, nil
+			},
+		},
+		"descriptor": &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if b := band(p); b != "" {
+					return b.Descriptor(), nil
+				}
+				return nil, nil
+			},
+		},
+		"advisory": &graphql.Field{
+			Description: "NEA's health advisory. 1-hour PM2.5 bands get the advisory of the PSI band they correspond to " +
+				"(NORMAL that of MODERATE, ELEVATED UNHEALTHY, HIGH VERY_UNHEALTHY and VERY_HIGH HAZARDOUS)",
+			Type: healthAdvisoryObject,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if advisory := band(p).Advisory(); advisory != (datagovsg.HealthAdvisory{}) {
+					return advisory, nil
+				}
+				return nil, nil
+			},
+		},
+	}
+	for name, field := range bandFields {
+		fields[name] = field
+	}
+	return fields
+}
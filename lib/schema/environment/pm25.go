@@ -2,19 +2,26 @@ package environment
 
 import (
 	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
 	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/common"
 )
 
 var pm25ReadingObject = graphql.NewObject(graphql.ObjectConfig{
 	Name: "PM25Reading",
-	Fields: graphql.Fields{
+	Fields: withHealthBand(graphql.Fields{
+		"region": &graphql.Field{
+			Type: graphql.NewNonNull(common.RegionEnum),
+		},
 		"value": &graphql.Field{
 			Type: graphql.NewNonNull(graphql.Int),
 		},
 		"area": &graphql.Field{
 			Type: graphql.NewNonNull(common.AreaObject),
 		},
-	},
+	}, func(p graphql.ResolveParams) datagovsg.HealthBand {
+		reading, _ := p.Source.(datagovsg.PM25Readings)
+		return reading.Band()
+	}),
 })
 
 var pm25ReadingRegionsObject = graphql.NewObject(graphql.ObjectConfig{
@@ -35,6 +42,20 @@ var pm25ReadingRegionsObject = graphql.NewObject(graphql.ObjectConfig{
 		"west": &graphql.Field{
 			Type: graphql.NewNonNull(pm25ReadingObject),
 		},
+		"readings": &graphql.Field{
+			Description: "Readings for the given regions, in the order given, or for all regions if none are given. " +
+				"There is no national PM2.5 reading.",
+			Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(pm25ReadingObject))),
+			Args: graphql.FieldConfigArgument{
+				"region": &graphql.ArgumentConfig{
+					Type: graphql.NewList(graphql.NewNonNull(common.RegionEnum)),
+				},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				regions, _ := p.Source.(datagovsg.PM25ReadingRegionsGraphQL)
+				return regions.Readings(common.RegionsFromArgs(p.Args["region"])), nil
+			},
+		},
 	},
 })
 
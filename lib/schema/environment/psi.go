@@ -1,20 +1,30 @@
 package environment
 
 import (
+	"fmt"
 	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
 	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/common"
+	"strings"
 )
 
 var psiReadingObject = graphql.NewObject(graphql.ObjectConfig{
 	Name: "PSIReading",
-	Fields: graphql.Fields{
+	Fields: withHealthBand(graphql.Fields{
+		"region": &graphql.Field{
+			Type: graphql.NewNonNull(common.RegionEnum),
+		},
 		"value": &graphql.Field{
 			Type: graphql.NewNonNull(graphql.Float),
 		},
 		"area": &graphql.Field{
 			Type: graphql.NewNonNull(common.AreaObject),
 		},
-	},
+	}, func(p graphql.ResolveParams) datagovsg.HealthBand {
+		// band, descriptor and advisory are null for pollutant concentrations
+		reading, _ := p.Source.(datagovsg.PSIReadings)
+		return reading.Band()
+	}),
 })
 
 var psiReadingRegionsObject = graphql.NewObject(graphql.ObjectConfig{
@@ -38,11 +48,58 @@ var psiReadingRegionsObject = graphql.NewObject(graphql.ObjectConfig{
 		"west": &graphql.Field{
 			Type: graphql.NewNonNull(psiReadingObject),
 		},
+		"readings": &graphql.Field{
+			Description: "Readings for the given regions, in the order given, or for all regions if none are given",
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(psiReadingObject))),
+			Args: graphql.FieldConfigArgument{
+				"region": &graphql.ArgumentConfig{
+					Type: graphql.NewList(graphql.NewNonNull(common.RegionEnum)),
+				},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				regions, _ := p.Source.(datagovsg.PSIReadingRegionsGraphQL)
+				return regions.Readings(common.RegionsFromArgs(p.Args["region"])), nil
+			},
+		},
 	},
 })
+var psiMetricEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "PSIMetric",
+	Description: "Readings reported by the PSI endpoint",
+	Values:      psiMetricValues(),
+})
+
+func psiMetricValues() graphql.EnumValueConfigMap {
+	values := graphql.EnumValueConfigMap{}
+	for _, metric := range datagovsg.PSIMetrics {
+		values[strings.ToUpper(metric.Name)] = &graphql.EnumValueConfig{
+			Value: metric.Name,
+		}
+	}
+	return values
+}
+
 var psiReadingIntervalsObject = graphql.NewObject(graphql.ObjectConfig{
 	Name: "PSIReadingIntervals",
 	Fields: graphql.Fields{
+		"metric": &graphql.Field{
+			Description: "Readings of the given metric",
+			Type:        graphql.NewNonNull(psiReadingRegionsObject),
+			Args: graphql.FieldConfigArgument{
+				"name": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(psiMetricEnum),
+				},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				intervals, _ := p.Source.(datagovsg.PSIReadingIntervalsGraphQL)
+				name, _ := p.Args["name"].(string)
+				regions, ok := intervals.ByMetric(name)
+				if !ok {
+					return nil, fmt.Errorf("unknown PSI metric: %v", name)
+				}
+				return regions, nil
+			},
+		},
 		"psi_twenty_four_hourly": &graphql.Field{
 			Type: graphql.NewNonNull(psiReadingRegionsObject),
 		},
@@ -58,9 +115,17 @@ var psiReadingIntervalsObject = graphql.NewObject(graphql.ObjectConfig{
 		"psi_three_hourly": &graphql.Field{
 			Type: graphql.NewNonNull(psiReadingRegionsObject),
 		},
-		"o2_twenty_four_hourly": &graphql.Field{
+		"so2_twenty_four_hourly": &graphql.Field{
 			Type: graphql.NewNonNull(psiReadingRegionsObject),
 		},
+		"o2_twenty_four_hourly": &graphql.Field{
+			Type:              graphql.NewNonNull(psiReadingRegionsObject),
+			DeprecationReason: "Misnamed, use so2_twenty_four_hourly. Deprecated 2026-10-19.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				intervals, _ := p.Source.(datagovsg.PSIReadingIntervalsGraphQL)
+				return intervals.SO2TwentyFourHourly, nil
+			},
+		},
 		"o3_sub_index": &graphql.Field{
 			Type: graphql.NewNonNull(psiReadingRegionsObject),
 		},
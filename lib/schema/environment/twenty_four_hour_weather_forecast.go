@@ -2,6 +2,7 @@ package environment
 
 import (
 	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
 	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/common"
 )
 
@@ -23,12 +24,17 @@ var regionWeatherForecastObject = graphql.NewObject(graphql.ObjectConfig{
 		"west": &graphql.Field{
 			Type: graphql.NewNonNull(graphql.String),
 		},
+		"south_condition":   regionConditionField("south"),
+		"north_condition":   regionConditionField("north"),
+		"east_condition":    regionConditionField("east"),
+		"central_condition": regionConditionField("central"),
+		"west_condition":    regionConditionField("west"),
 	},
 })
 
 var generalTwentyFourHourWeatherForecastObject = graphql.NewObject(graphql.ObjectConfig{
 	Name: "GeneralTwentyFourHourWeatherForecast",
-	Fields: graphql.Fields{
+	Fields: withForecastCondition(graphql.Fields{
 		"forecast": &graphql.Field{
 			Type: graphql.NewNonNull(graphql.String),
 		},
@@ -41,7 +47,10 @@ var generalTwentyFourHourWeatherForecastObject = graphql.NewObject(graphql.Objec
 		"wind": &graphql.Field{
 			Type: graphql.NewNonNull(common.WindObject),
 		},
-	},
+	}, func(p graphql.ResolveParams) string {
+		forecast, _ := p.Source.(datagovsg.GeneralTwentyFourHourWeatherForecast)
+		return forecast.Forecast
+	}),
 })
 
 var twentyFourHourWeatherForecastObject = graphql.NewObject(graphql.ObjectConfig{
@@ -2,19 +2,23 @@ package environment
 
 import (
 	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
 	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/common"
 )
 
 var twoHourWeatherForecastObject = graphql.NewObject(graphql.ObjectConfig{
 	Name: "TwoHourWeatherForecast",
-	Fields: graphql.Fields{
+	Fields: withForecastCondition(graphql.Fields{
 		"area": &graphql.Field{
 			Type: graphql.NewNonNull(common.AreaObject),
 		},
 		"forecast": &graphql.Field{
 			Type: graphql.NewNonNull(graphql.String),
 		},
-	},
+	}, func(p graphql.ResolveParams) string {
+		forecast, _ := p.Source.(datagovsg.TwoHourWeatherForecastGraphQL)
+		return forecast.Forecast
+	}),
 })
 
 var twoHourWeatherForecastResultItemObject = graphql.NewObject(graphql.ObjectConfig{
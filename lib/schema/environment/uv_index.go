@@ -2,6 +2,7 @@ package environment
 
 import (
 	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
 	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/common"
 )
 
@@ -29,6 +30,31 @@ var uvIndexReadingsResultItemObject = graphql.NewObject(graphql.ObjectConfig{
 		"index": &graphql.Field{
 			Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(uvIndexReadingObject))),
 		},
+		"peak": &graphql.Field{
+			Description: "Highest hourly reading of the day so far (the earliest, if tied)",
+			Type:        uvIndexReadingObject,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				item, _ := p.Source.(datagovsg.UVIndexReadingsResultItem)
+				if peak := item.Peak(); peak != nil {
+					return *peak, nil
+				}
+				return nil, nil
+			},
+		},
+		"hours_at_or_above": &graphql.Field{
+			Description: "Number of hourly readings of the day so far at or above an exposure category",
+			Type:        graphql.NewNonNull(graphql.Int),
+			Args: graphql.FieldConfigArgument{
+				"category": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(uvCategoryEnum),
+				},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				item, _ := p.Source.(datagovsg.UVIndexReadingsResultItem)
+				category, _ := p.Args["category"].(string)
+				return item.HoursAtOrAbove(datagovsg.UVCategory(category)), nil
+			},
+		},
 	},
 })
 
@@ -41,5 +67,47 @@ var uvIndexReadingObject = graphql.NewObject(graphql.ObjectConfig{
 		"timestamp": &graphql.Field{
 			Type: graphql.NewNonNull(graphql.String),
 		},
+		"category": &graphql.Field{
+			Type: graphql.NewNonNull(uvCategoryEnum),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				reading, _ := p.Source.(datagovsg.UVIndexReading)
+				return string(reading.Category()), nil
+			},
+		},
+		"advice": &graphql.Field{
+			Description: "Sun protection advice for the exposure category",
+			Type:        graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				reading, _ := p.Source.(datagovsg.UVIndexReading)
+				return reading.Category().Advice(), nil
+			},
+		},
+	},
+})
+
+var uvCategoryEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "UVCategory",
+	Description: "WHO UV index exposure category",
+	Values: graphql.EnumValueConfigMap{
+		"LOW": &graphql.EnumValueConfig{
+			Value:       string(datagovsg.UVCategoryLow),
+			Description: "UV index 0-2",
+		},
+		"MODERATE": &graphql.EnumValueConfig{
+			Value:       string(datagovsg.UVCategoryModerate),
+			Description: "UV index 3-5",
+		},
+		"HIGH": &graphql.EnumValueConfig{
+			Value:       string(datagovsg.UVCategoryHigh),
+			Description: "UV index 6-7",
+		},
+		"VERY_HIGH": &graphql.EnumValueConfig{
+			Value:       string(datagovsg.UVCategoryVeryHigh),
+			Description: "UV index 8-10",
+		},
+		"EXTREME": &graphql.EnumValueConfig{
+			Value:       string(datagovsg.UVCategoryExtreme),
+			Description: "UV index 11 and above",
+		},
 	},
 })
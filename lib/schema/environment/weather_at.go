@@ -0,0 +1,138 @@
+package environment
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/common"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/geojson"
+	"time"
+)
+
+var weatherAtObject = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "WeatherAt",
+	Description: "Forecasts and readings relevant to a single location",
+	Fields: graphql.Fields{
+		"location": &graphql.Field{
+			Type: graphql.NewNonNull(common.LocationObject),
+		},
+		"region": &graphql.Field{
+			Description: "PSI/PM2.5 region the location falls in",
+			Type:        graphql.NewNonNull(graphql.String),
+		},
+		"area": &graphql.Field{
+			Description: "Two-hour weather forecast area nearest to the location",
+			Type:        graphql.NewNonNull(common.AreaObject),
+		},
+		"forecast": &graphql.Field{
+			Description: "Two-hour weather forecast for the nearest area",
+			Type:        graphql.NewNonNull(graphql.String),
+		},
+		"valid_period": &graphql.Field{
+			Type: graphql.NewNonNull(common.DateTimeRangeObject),
+		},
+		"region_forecast": &graphql.Field{
+			Description: "Twenty-four hour weather forecast for the region, for the period matching date_time",
+			Type:        graphql.NewNonNull(graphql.String),
+		},
+		"region_forecast_period": &graphql.Field{
+			Type: graphql.NewNonNull(common.DateTimeRangeObject),
+		},
+		"general": &graphql.Field{
+			Type: graphql.NewNonNull(generalTwentyFourHourWeatherForecastObject),
+		},
+		"psi_twenty_four_hourly": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Float),
+		},
+		"psi_three_hourly": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Float),
+		},
+		"pm25_one_hourly": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Int),
+		},
+		"uv_index": &graphql.Field{
+			Type: graphql.NewNonNull(uvIndexReadingObject),
+		},
+	},
+})
+
+// WeatherAtField returns the root field that combines the two-hour and twenty-four hour weather forecasts,
+// PSI, PM2.5 and UV index readings for a location.
+// All datasets are requested at once through the shared client, so they are fetched concurrently and
+// coalesced with any other field in the query asking for the same dataset.
+func WeatherAtField() *graphql.Field {
+	return &graphql.Field{
+		Name: "Weather At",
+		Type: graphql.NewNonNull(weatherAtObject),
+		Args: graphql.FieldConfigArgument{
+			"latitude": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.Float),
+			},
+			"longitude": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.Float),
+			},
+			"date_time": &graphql.ArgumentConfig{
+				Type: common.DateTimeStringScalar,
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+
+			c := datagovsg.GetClientFromContext(p.Context)
+
+			latitude, _ := p.Args["latitude"].(float64)
+			longitude, _ := p.Args["longitude"].(float64)
+			dateTime, _ := p.Args["date_time"].(string)
+
+			at := time.Now()
+			if dateTime != "" {
+				t, err := datagovsg.ParseTimestamp(dateTime)
+				if err != nil {
+					return nil, err
+				}
+				at = t
+			}
+
+			// fire off all requests before waiting on any of them
+			twoHourCh := c.GetContext(p.Context, twoHourWeatherForecastURL(dateTime, ""), &datagovsg.TwoHourWeatherForecastResult{})
+			twentyFourHourCh := c.GetContext(p.Context, twentyFourHourWeatherForecastURL(dateTime, ""), &datagovsg.TwentyFourHourWeatherForecastResult{})
+			psiCh := c.GetContext(p.Context, psiURL(dateTime, ""), &datagovsg.PSIReadingsResult{})
+			pm25Ch := c.GetContext(p.Context, pm25URL(dateTime, ""), &datagovsg.PM25ReadingsResult{})
+			uvIndexCh := c.GetContext(p.Context, uvIndexURL(dateTime, ""), &datagovsg.UVIndexReadingsResult{})
+
+			src := datagovsg.WeatherAtSources{}
+			var err error
+			for _, ch := range []chan datagovsg.ClientResult{twoHourCh, twentyFourHourCh, psiCh, pm25Ch, uvIndexCh} {
+				res := <-ch
+				if res.Err != nil {
+					// keep draining the remaining channels so that no broadcast is left blocked
+					err = res.Err
+					continue
+				}
+				switch body := res.Body.(type) {
+				case *datagovsg.TwoHourWeatherForecastResult:
+					src.TwoHourWeatherForecast = body
+				case *datagovsg.TwentyFourHourWeatherForecastResult:
+					src.TwentyFourHourWeatherForecast = body
+				case *datagovsg.PSIReadingsResult:
+					src.PSI = body
+				case *datagovsg.PM25ReadingsResult:
+					src.PM25 = body
+				case *datagovsg.UVIndexReadingsResult:
+					src.UVIndex = body
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			loc := datagovsg.Location{
+				Latitude:  latitude,
+				Longitude: longitude,
+			}
+			region := ""
+			if boundary := geojson.DefaultBoundaries.RegionAt(longitude, latitude); boundary != nil {
+				region = boundary.Name
+			}
+			return datagovsg.NewWeatherAt(loc, region, at, src), nil
+		},
+	}
+}
@@ -0,0 +1,271 @@
+package geojson
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Boundary is a named area (a planning area, or a region made up of planning areas) bounded by one or more polygons
+type Boundary struct {
+	Name         string
+	Region       string
+	MultiPolygon [][][][]float64
+
+	bbox []float64
+}
+
+// Contains returns true if the given location falls within the boundary
+func (b *Boundary) Contains(longitude, latitude float64) bool {
+	bbox := b.bbox
+	if bbox == nil {
+		bbox = BoundingBox(b.MultiPolygon)
+	}
+	if len(bbox) != 4 ||
+		longitude < bbox[0] || latitude < bbox[1] || longitude > bbox[2] || latitude > bbox[3] {
+		return false
+	}
+	return PointInMultiPolygon(longitude, latitude, b.MultiPolygon)
+}
+
+// Feature returns the boundary as a GeoJSON Feature, in a form that GeoJSONInterface can resolve
+func (b *Boundary) Feature() map[string]interface{} {
+	feature := map[string]interface{}{
+		"type": "Feature",
+		"crs": map[string]interface{}{
+			"type": "name",
+			"properties": map[string]interface{}{
+				"name": "urn:ogc:def:crs:OGC:1.3:CRS84",
+			},
+		},
+		"id":   b.Name,
+		"bbox": BoundingBox(b.MultiPolygon),
+		"geometry": map[string]interface{}{
+			"type":        "MultiPolygon",
+			"coordinates": b.MultiPolygon,
+		},
+		"properties": map[string]interface{}{
+			"name":   b.Name,
+			"region": b.Region,
+		},
+	}
+
+	// marshal-unmarshal to map[string]interface{}, so that coordinates are coerced as nested []interface{}
+	featureMap := map[string]interface{}{}
+	bytes, _ := json.Marshal(feature)
+	json.Unmarshal(bytes, &featureMap)
+	return featureMap
+}
+
+// Boundaries is a point-in-polygon lookup service for planning areas and the regions they belong to
+type Boundaries struct {
+	PlanningAreas []*Boundary
+	Regions       []*Boundary
+}
+
+// NewBoundaries groups planning areas into regions
+func NewBoundaries(planningAreas []*Boundary) *Boundaries {
+	b := &Boundaries{
+		PlanningAreas: planningAreas,
+	}
+	regions := map[string]*Boundary{}
+	for _, area := range planningAreas {
+		if area.Region == "" {
+			continue
+		}
+		region, ok := regions[area.Region]
+		if !ok {
+			region = &Boundary{
+				Name:   area.Region,
+				Region: area.Region,
+			}
+			regions[area.Region] = region
+			b.Regions = append(b.Regions, region)
+		}
+		region.MultiPolygon = append(region.MultiPolygon, area.MultiPolygon...)
+	}
+
+	// pre-compute bounding boxes, so that lookups don't have to
+	for _, area := range b.PlanningAreas {
+		area.bbox = BoundingBox(area.MultiPolygon)
+	}
+	for _, region := range b.Regions {
+		region.bbox = BoundingBox(region.MultiPolygon)
+	}
+	return b
+}
+
+// PlanningAreaAt returns the planning area that contains the given location, or nil if none does
+func (b *Boundaries) PlanningAreaAt(longitude, latitude float64) *Boundary {
+	for _, area := range b.PlanningAreas {
+		if area.Contains(longitude, latitude) {
+			return area
+		}
+	}
+	return nil
+}
+
+// RegionAt returns the region that contains the given location, or nil if none does
+func (b *Boundaries) RegionAt(longitude, latitude float64) *Boundary {
+	area := b.PlanningAreaAt(longitude, latitude)
+	if area == nil {
+		return nil
+	}
+	return b.Region(area.Region)
+}
+
+// PlanningArea returns the planning area with the given name (case-insensitive), or nil if there is none
+func (b *Boundaries) PlanningArea(name string) *Boundary {
+	for _, area := range b.PlanningAreas {
+		if strings.EqualFold(area.Name, name) {
+			return area
+		}
+	}
+	return nil
+}
+
+// Region returns the region with the given name (case-insensitive), or nil if there is none
+func (b *Boundaries) Region(name string) *Boundary {
+	for _, region := range b.Regions {
+		if strings.EqualFold(region.Name, name) {
+			return region
+		}
+	}
+	return nil
+}
+
+type boundaryFeatureCollection struct {
+	Features []struct {
+		Properties map[string]interface{} `json:"properties"`
+		Geometry   struct {
+			Type        string          `json:"type"`
+			Coordinates json.RawMessage `json:"coordinates"`
+		} `json:"geometry"`
+	} `json:"features"`
+}
+
+// PlanningAreaRegions maps the URA Master Plan 2019 planning areas to the PSI/PM2.5 regions. NEA does not publish
+// which planning areas make up each region, so each is assigned to the region whose label location (the
+// region_metadata of the PSI and PM2.5 endpoints) is nearest the centre of the planning area.
+var PlanningAreaRegions = map[string]string{
+	"ANG MO KIO":              "central",
+	"BEDOK":                   "east",
+	"BISHAN":                  "central",
+	"BOON LAY":                "west",
+	"BUKIT BATOK":             "west",
+	"BUKIT MERAH":             "south",
+	"BUKIT PANJANG":           "central",
+	"BUKIT TIMAH":             "central",
+	"CENTRAL WATER CATCHMENT": "central",
+	"CHANGI":                  "east",
+	"CHANGI BAY":              "east",
+	"CHOA CHU KANG":           "west",
+	"CLEMENTI":                "south",
+	"DOWNTOWN CORE":           "south",
+	"GEYLANG":                 "east",
+	"HOUGANG":                 "east",
+	"JURONG EAST":             "west",
+	"JURONG WEST":             "west",
+	"KALLANG":                 "south",
+	"LIM CHU KANG":            "west",
+	"MANDAI":                  "north",
+	"MARINA EAST":             "south",
+	"MARINA SOUTH":            "south",
+	"MARINE PARADE":           "east",
+	"MUSEUM":                  "south",
+	"NEWTON":                  "south",
+	"NORTH-EASTERN ISLANDS":   "east",
+	"NOVENA":                  "south",
+	"ORCHARD":                 "south",
+	"OUTRAM":                  "south",
+	"PASIR RIS":               "east",
+	"PAYA LEBAR":              "east",
+	"PIONEER":                 "west",
+	"PUNGGOL":                 "east",
+	"QUEENSTOWN":              "south",
+	"RIVER VALLEY":            "south",
+	"ROCHOR":                  "south",
+	"SELETAR":                 "north",
+	"SEMBAWANG":               "north",
+	"SENGKANG":                "east",
+	"SERANGOON":               "central",
+	"SIMPANG":                 "north",
+	"SINGAPORE RIVER":         "south",
+	"SOUTHERN ISLANDS":        "south",
+	"STRAITS VIEW":            "south",
+	"SUNGEI KADUT":            "north",
+	"TAMPINES":                "east",
+	"TANGLIN":                 "south",
+	"TENGAH":                  "west",
+	"TOA PAYOH":               "central",
+	"TUAS":                    "west",
+	"WESTERN ISLANDS":         "west",
+	"WESTERN WATER CATCHMENT": "west",
+	"WOODLANDS":               "north",
+	"YISHUN":                  "north",
+}
+
+var psiRegions = map[string]bool{
+	"central": true,
+	"east":    true,
+	"north":   true,
+	"south":   true,
+	"west":    true,
+}
+
+// LoadBoundaries reads planning area boundaries from a GeoJSON FeatureCollection of Polygons or MultiPolygons,
+// such as the URA Master Plan planning area boundary dataset on data.gov.sg.
+// Planning area names are read from the "name" or "PLN_AREA_N" feature property. Regions are read from the
+// "region" property, which must be a PSI/PM2.5 region (north, south, east, west or central), or else looked up in
+// PlanningAreaRegions. Features without a name or a region are an error.
+func LoadBoundaries(r io.Reader) (*Boundaries, error) {
+	fc := boundaryFeatureCollection{}
+	if err := json.NewDecoder(r).Decode(&fc); err != nil {
+		return nil, err
+	}
+	areas := []*Boundary{}
+	for i, feature := range fc.Features {
+		area := &Boundary{
+			Name: propertyString(feature.Properties, "name", "PLN_AREA_N"),
+		}
+		if area.Name == "" {
+			return nil, fmt.Errorf("feature %v: no name or PLN_AREA_N property", i)
+		}
+		if region := propertyString(feature.Properties, "region"); region != "" {
+			if !psiRegions[strings.ToLower(region)] {
+				return nil, fmt.Errorf("feature %v (%v): unknown region %q", i, area.Name, region)
+			}
+			area.Region = strings.ToLower(region)
+		} else if region, ok := PlanningAreaRegions[strings.ToUpper(area.Name)]; ok {
+			area.Region = region
+		} else {
+			return nil, fmt.Errorf("feature %v (%v): not a URA planning area, and no region property", i, area.Name)
+		}
+		switch feature.Geometry.Type {
+		case "Polygon":
+			polygon := [][][]float64{}
+			if err := json.Unmarshal(feature.Geometry.Coordinates, &polygon); err != nil {
+				return nil, fmt.Errorf("feature %v (%v): %v", i, area.Name, err)
+			}
+			area.MultiPolygon = [][][][]float64{polygon}
+		case "MultiPolygon":
+			if err := json.Unmarshal(feature.Geometry.Coordinates, &area.MultiPolygon); err != nil {
+				return nil, fmt.Errorf("feature %v (%v): %v", i, area.Name, err)
+			}
+		default:
+			return nil, fmt.Errorf("feature %v (%v): unsupported geometry type %q", i, area.Name, feature.Geometry.Type)
+		}
+		areas = append(areas, area)
+	}
+	return NewBoundaries(areas), nil
+}
+
+func propertyString(properties map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if s, ok := properties[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package geojson
+
+import (
+	"strings"
+)
+
+// DefaultBoundaries are the planning area boundaries used by the schema, and are replaced by the boundaries file
+// given in the configuration, if any.
+// The embedded boundaries are simplified outlines of the 55 URA Master Plan 2019 planning areas, accurate to a few
+// hundred metres: lookups near the border between two planning areas may return the other one. Load the full
+// planning area boundary dataset from data.gov.sg with LoadBoundaries for exact lookups.
+var DefaultBoundaries *Boundaries
+
+func init() {
+	var err error
+	DefaultBoundaries, err = LoadBoundaries(strings.NewReader(boundariesJSON))
+	if err != nil {
+		panic(err)
+	}
+}
+
+const boundariesJSON = `{"type":"FeatureCollection","features":[
+	{"type":"Feature","properties":{"PLN_AREA_N":"TUAS","REGION_N":"WEST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.6348,1.3485],[103.622,1.34],[103.608,1.325],[103.6071,1.3176],[103.605,1.3],[103.61,1.265],[103.63,1.255],[103.65,1.268],[103.6673,1.2853],[103.6579,1.2975],[103.6695,1.3173],[103.6674,1.3279],[103.6715,1.335],[103.667,1.343],[103.6424,1.3564],[103.64,1.352],[103.6348,1.3485]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"PIONEER","REGION_N":"WEST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.6736,1.3353],[103.6715,1.335],[103.6674,1.3279],[103.6695,1.3173],[103.6579,1.2975],[103.6673,1.2853],[103.67,1.288],[103.6912,1.2951],[103.6897,1.3009],[103.6963,1.3172],[103.7064,1.324],[103.7075,1.3261],[103.6938,1.3375],[103.681,1.3389],[103.6736,1.3353]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"BOON LAY","REGION_N":"WEST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.6912,1.2951],[103.7,1.298],[103.7176,1.2998],[103.72,1.3],[103.7297,1.3019],[103.7299,1.3034],[103.7248,1.3254],[103.7089,1.327],[103.7075,1.3261],[103.7064,1.324],[103.6963,1.3172],[103.6897,1.3009],[103.6912,1.2951]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"JURONG WEST","REGION_N":"WEST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.6938,1.3375],[103.7075,1.3261],[103.7089,1.327],[103.7248,1.3254],[103.7305,1.3308],[103.7311,1.3355],[103.7247,1.3477],[103.7243,1.3477],[103.7125,1.3595],[103.702,1.3572],[103.6936,1.3624],[103.681,1.3389],[103.6938,1.3375]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"JURONG EAST","REGION_N":"WEST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.7311,1.3355],[103.7305,1.3308],[103.7248,1.3254],[103.7299,1.3034],[103.7297,1.3019],[103.735,1.303],[103.7516,1.2937],[103.7565,1.3038],[103.7534,1.3239],[103.7555,1.3265],[103.7556,1.3282],[103.7499,1.3397],[103.7458,1.3416],[103.7392,1.3585],[103.7361,1.3591],[103.7247,1.3477],[103.7311,1.3355]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"CLEMENTI","REGION_N":"WEST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.7555,1.3265],[103.7534,1.3239],[103.7565,1.3038],[103.7516,1.2937],[103.76,1.289],[103.7727,1.2831],[103.779,1.302],[103.775,1.31],[103.778,1.319],[103.78,1.3205],[103.78,1.332],[103.769,1.3393],[103.7556,1.3282],[103.7555,1.3265]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"BUKIT BATOK","REGION_N":"WEST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.7392,1.3585],[103.7458,1.3416],[103.7499,1.3397],[103.7556,1.3282],[103.769,1.3393],[103.769,1.346],[103.778,1.355],[103.7659,1.3631],[103.7647,1.3718],[103.7579,1.3792],[103.7534,1.3762],[103.7469,1.3613],[103.7392,1.3585]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"BUKIT PANJANG","REGION_N":"WEST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.7663,1.3985],[103.7611,1.3915],[103.7579,1.3792],[103.7647,1.3718],[103.7659,1.3631],[103.778,1.355],[103.7823,1.3559],[103.7874,1.3602],[103.7859,1.3737],[103.7884,1.3825],[103.7827,1.392],[103.7725,1.3981],[103.7663,1.3985]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"CHOA CHU KANG","REGION_N":"WEST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.7534,1.3762],[103.7579,1.3792],[103.7611,1.3915],[103.7663,1.3985],[103.7657,1.3994],[103.7386,1.4106],[103.7259,1.4008],[103.73,1.3915],[103.73,1.3852],[103.7317,1.3812],[103.7306,1.3684],[103.7361,1.3591],[103.7392,1.3585],[103.7469,1.3613],[103.7534,1.3762]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"TENGAH","REGION_N":"WEST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.7125,1.3595],[103.7243,1.3477],[103.7247,1.3477],[103.7361,1.3591],[103.7306,1.3684],[103.7317,1.3812],[103.73,1.3852],[103.7112,1.3766],[103.7012,1.3808],[103.6902,1.3718],[103.69,1.3701],[103.6936,1.3624],[103.702,1.3572],[103.7125,1.3595]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"WESTERN WATER CATCHMENT","REGION_N":"WEST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.6756,1.4102],[103.672,1.405],[103.6659,1.3961],[103.655,1.38],[103.6535,1.3772],[103.6424,1.3564],[103.667,1.343],[103.6715,1.335],[103.6736,1.3353],[103.681,1.3389],[103.6936,1.3624],[103.69,1.3701],[103.6902,1.3718],[103.7012,1.3808],[103.7112,1.3766],[103.73,1.3852],[103.73,1.3915],[103.7259,1.4008],[103.7207,1.4028],[103.7152,1.4107],[103.6858,1.4062],[103.6756,1.4102]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"WESTERN ISLANDS","REGION_N":"WEST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.6991,1.2389],[103.7052,1.2409],[103.718,1.245],[103.735,1.268],[103.7251,1.2817],[103.722,1.286],[103.7,1.29],[103.6861,1.2807],[103.682,1.278],[103.666,1.262],[103.66,1.245],[103.69,1.236],[103.6991,1.2389]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"LIM CHU KANG","REGION_N":"NORTH REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.7266,1.4387],[103.7252,1.4485],[103.7175,1.4496],[103.715,1.45],[103.7,1.44],[103.688,1.428],[103.687,1.4265],[103.6756,1.4102],[103.6858,1.4062],[103.7152,1.4107],[103.7207,1.4028],[103.7259,1.4008],[103.7386,1.4106],[103.741,1.4224],[103.7266,1.4387]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"SUNGEI KADUT","REGION_N":"NORTH REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.741,1.4224],[103.7386,1.4106],[103.7657,1.3994],[103.7639,1.4137],[103.7686,1.424],[103.7592,1.4401],[103.7595,1.4426],[103.7574,1.4474],[103.752,1.445],[103.735,1.447],[103.7252,1.4485],[103.7266,1.4387],[103.741,1.4224]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"WOODLANDS","REGION_N":"NORTH REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.7811,1.4266],[103.7886,1.4221],[103.7973,1.4233],[103.799,1.425],[103.8156,1.4305],[103.8159,1.4313],[103.8051,1.4582],[103.795,1.452],[103.7894,1.4528],[103.78,1.454],[103.768,1.452],[103.7574,1.4474],[103.7595,1.4426],[103.7592,1.4401],[103.7686,1.424],[103.7811,1.4266]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"SEMBAWANG","REGION_N":"NORTH REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8149,1.4651],[103.808,1.46],[103.8051,1.4582],[103.8159,1.4313],[103.8268,1.4394],[103.8443,1.4359],[103.849,1.4398],[103.849,1.4605],[103.8493,1.4611],[103.848,1.462],[103.835,1.466],[103.82,1.469],[103.8149,1.4651]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"YISHUN","REGION_N":"NORTH REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8443,1.4359],[103.8268,1.4394],[103.8159,1.4313],[103.8156,1.4305],[103.8194,1.4253],[103.8213,1.4113],[103.8385,1.3975],[103.8423,1.3989],[103.8438,1.4003],[103.8444,1.4022],[103.856,1.4154],[103.8558,1.4176],[103.8611,1.43],[103.8568,1.4361],[103.849,1.4398],[103.8443,1.4359]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"MANDAI","REGION_N":"NORTH REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.7973,1.4233],[103.7886,1.4221],[103.7811,1.4266],[103.7686,1.424],[103.7639,1.4137],[103.7657,1.3994],[103.7663,1.3985],[103.7725,1.3981],[103.7827,1.392],[103.8008,1.406],[103.8091,1.4011],[103.8107,1.3948],[103.8288,1.3888],[103.832,1.3894],[103.8385,1.3975],[103.8213,1.4113],[103.8194,1.4253],[103.8156,1.4305],[103.799,1.425],[103.7973,1.4233]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"SIMPANG","REGION_N":"NORTH REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8662,1.4442],[103.862,1.452],[103.8493,1.4611],[103.849,1.4605],[103.849,1.4398],[103.8568,1.4361],[103.8611,1.43],[103.8845,1.43],[103.8846,1.4302],[103.87,1.437],[103.8662,1.4442]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"CENTRAL WATER CATCHMENT","REGION_N":"NORTH REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.7884,1.3825],[103.7859,1.3737],[103.7874,1.3602],[103.799,1.3566],[103.8097,1.3423],[103.8204,1.3401],[103.8243,1.3625],[103.8298,1.3654],[103.8362,1.3728],[103.832,1.3894],[103.8288,1.3888],[103.8107,1.3948],[103.8091,1.4011],[103.8008,1.406],[103.7827,1.392],[103.7884,1.3825]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"SELETAR","REGION_N":"NORTH-EAST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8714,1.4023],[103.8845,1.4085],[103.883,1.423],[103.8845,1.43],[103.8611,1.43],[103.8558,1.4176],[103.856,1.4154],[103.8444,1.4022],[103.8438,1.4003],[103.8668,1.3949],[103.8714,1.4023]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"PUNGGOL","REGION_N":"NORTH-EAST REGION"},"geometry":{"type":"MultiPolygon","coordinates":[[[[103.9133,1.4146],[103.9104,1.4163],[103.902,1.421],[103.885,1.43],[103.8846,1.4302],[103.8845,1.43],[103.883,1.423],[103.8845,1.4085],[103.8889,1.4041],[103.8918,1.4032],[103.8992,1.3958],[103.9071,1.3938],[103.92,1.3825],[103.9299,1.3937],[103.9257,1.4043],[103.918,1.412],[103.9133,1.4146]]],[[[103.92,1.406],[103.9256,1.4046],[103.9236,1.4096],[103.92,1.406]]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"SENGKANG","REGION_N":"NORTH-EAST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8992,1.3958],[103.8918,1.4032],[103.8889,1.4041],[103.8845,1.4085],[103.8714,1.4023],[103.8668,1.3949],[103.8706,1.3842],[103.881,1.3852],[103.8888,1.38],[103.8912,1.38],[103.8987,1.375],[103.9061,1.3743],[103.9193,1.3772],[103.92,1.3825],[103.9071,1.3938],[103.8992,1.3958]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"HOUGANG","REGION_N":"NORTH-EAST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8987,1.375],[103.8912,1.38],[103.8888,1.38],[103.881,1.3852],[103.8706,1.3842],[103.8695,1.3824],[103.8716,1.37],[103.876,1.3653],[103.8757,1.3629],[103.8774,1.3578],[103.8945,1.3424],[103.8961,1.3503],[103.896,1.3507],[103.9061,1.3743],[103.8987,1.375]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"SERANGOON","REGION_N":"NORTH-EAST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8774,1.3578],[103.8757,1.3629],[103.876,1.3653],[103.8716,1.37],[103.8571,1.3643],[103.8542,1.3583],[103.8575,1.3525],[103.8582,1.3492],[103.8679,1.3464],[103.8819,1.3335],[103.8946,1.3391],[103.8949,1.3397],[103.8945,1.3424],[103.8774,1.3578]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"ANG MO KIO","REGION_N":"NORTH-EAST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8542,1.3583],[103.8571,1.3643],[103.8716,1.37],[103.8695,1.3824],[103.8706,1.3842],[103.8668,1.3949],[103.8438,1.4003],[103.8423,1.3989],[103.8385,1.3975],[103.832,1.3894],[103.8362,1.3728],[103.8298,1.3654],[103.8416,1.3556],[103.8504,1.3593],[103.8542,1.3583]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"NORTH-EASTERN ISLANDS","REGION_N":"NORTH-EAST REGION"},"geometry":{"type":"MultiPolygon","coordinates":[[[[103.9368,1.3949],[103.93,1.4],[103.9257,1.4043],[103.9299,1.3937],[103.9368,1.3949]]],[[[103.9256,1.4046],[103.94,1.401],[103.9475,1.4007],[103.9475,1.4204],[103.93,1.416],[103.9236,1.4096],[103.9256,1.4046]]],[[[103.9475,1.4008],[103.97,1.4],[103.9702,1.4],[103.972,1.4204],[103.95,1.421],[103.9475,1.4204],[103.9475,1.4008]]],[[[103.9865,1.3911],[103.9818,1.39],[103.9845,1.3898],[103.9865,1.3911]]],[[[103.9702,1.4],[103.99,1.405],[103.986,1.42],[103.972,1.4204],[103.9702,1.4]]],[[[104.02,1.4],[104.04,1.391],[104.0452,1.3917],[104.0499,1.4241],[104.03,1.421],[104.02,1.4]]],[[[104.0452,1.3917],[104.07,1.395],[104.082,1.41],[104.062,1.426],[104.0499,1.4241],[104.0452,1.3917]]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"PASIR RIS","REGION_N":"EAST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.95,1.385],[103.9491,1.3857],[103.9368,1.3949],[103.9299,1.3937],[103.92,1.3825],[103.9193,1.3772],[103.9215,1.3729],[103.9371,1.3666],[103.9405,1.3685],[103.9565,1.3685],[103.9633,1.3584],[103.9725,1.3566],[103.9725,1.3876],[103.97,1.387],[103.95,1.385]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"TAMPINES","REGION_N":"EAST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.9333,1.34],[103.9417,1.335],[103.9483,1.335],[103.9567,1.33],[103.9709,1.33],[103.9739,1.3548],[103.9732,1.3562],[103.9725,1.3566],[103.9633,1.3584],[103.9565,1.3685],[103.9405,1.3685],[103.9371,1.3666],[103.9215,1.3729],[103.92,1.3674],[103.92,1.3451],[103.9279,1.34],[103.9333,1.34]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"BEDOK","REGION_N":"EAST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.928,1.3021],[103.9311,1.3033],[103.952,1.311],[103.9842,1.3139],[103.9839,1.3179],[103.9709,1.33],[103.9567,1.33],[103.9483,1.335],[103.9417,1.335],[103.9333,1.34],[103.9279,1.34],[103.92,1.3451],[103.8949,1.3397],[103.8946,1.3391],[103.9063,1.3182],[103.9162,1.3149],[103.928,1.3021]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"CHANGI","REGION_N":"EAST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.9732,1.3562],[103.9739,1.3548],[103.9709,1.33],[103.9839,1.3179],[104.0044,1.3376],[104.0134,1.3342],[104.0413,1.3482],[104.042,1.35],[104.033,1.372],[104.0273,1.3765],[104.01,1.39],[103.99,1.392],[103.9865,1.3911],[103.9845,1.3898],[103.9818,1.39],[103.9725,1.3876],[103.9725,1.3566],[103.9732,1.3562]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"CHANGI BAY","REGION_N":"EAST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.9842,1.3139],[103.985,1.314],[104.01,1.312],[104.0225,1.3177],[104.032,1.322],[104.0413,1.3482],[104.0134,1.3342],[104.0044,1.3376],[103.9839,1.3179],[103.9842,1.3139]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"PAYA LEBAR","REGION_N":"EAST REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8961,1.3503],[103.8945,1.3424],[103.8949,1.3397],[103.92,1.3451],[103.92,1.3674],[103.9215,1.3729],[103.9193,1.3772],[103.9061,1.3743],[103.896,1.3507],[103.8961,1.3503]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"BISHAN","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8582,1.3492],[103.8575,1.3525],[103.8542,1.3583],[103.8504,1.3593],[103.8416,1.3556],[103.8298,1.3654],[103.8243,1.3625],[103.8204,1.3401],[103.8209,1.3396],[103.8269,1.3388],[103.832,1.3396],[103.8371,1.3386],[103.8462,1.34],[103.8568,1.3471],[103.8582,1.3492]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"TOA PAYOH","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8568,1.3471],[103.8462,1.34],[103.8371,1.3386],[103.8493,1.319],[103.855,1.3276],[103.8619,1.3302],[103.8729,1.3268],[103.8804,1.3317],[103.8819,1.3335],[103.8679,1.3464],[103.8582,1.3492],[103.8568,1.3471]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"NOVENA","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8493,1.319],[103.8371,1.3386],[103.832,1.3396],[103.8269,1.3388],[103.8209,1.3396],[103.8179,1.3246],[103.825,1.3113],[103.8299,1.3119],[103.8365,1.3194],[103.8491,1.3173],[103.8493,1.319]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"NEWTON","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8491,1.3173],[103.8365,1.3194],[103.8299,1.3119],[103.8416,1.3041],[103.8435,1.3053],[103.8494,1.3164],[103.8491,1.3173]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"ORCHARD","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8416,1.3041],[103.8299,1.3119],[103.825,1.3113],[103.8203,1.3011],[103.8255,1.2985],[103.8415,1.3038],[103.8416,1.3041]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"TANGLIN","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.825,1.3113],[103.8179,1.3246],[103.8051,1.3148],[103.7958,1.3162],[103.7938,1.3054],[103.8129,1.2978],[103.8139,1.2982],[103.814,1.2983],[103.8203,1.3011],[103.825,1.3113]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"BUKIT TIMAH","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.78,1.332],[103.78,1.3205],[103.7943,1.3179],[103.7958,1.3162],[103.8051,1.3148],[103.8179,1.3246],[103.8209,1.3396],[103.8204,1.3401],[103.8097,1.3423],[103.799,1.3566],[103.7874,1.3602],[103.7823,1.3559],[103.778,1.355],[103.769,1.346],[103.769,1.3393],[103.78,1.332]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"QUEENSTOWN","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.7727,1.2831],[103.7762,1.2814],[103.79,1.275],[103.801,1.2728],[103.801,1.2898],[103.8129,1.2978],[103.7938,1.3054],[103.7958,1.3162],[103.7943,1.3179],[103.78,1.3205],[103.778,1.319],[103.775,1.31],[103.779,1.302],[103.7727,1.2831]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"BUKIT MERAH","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8093,1.2711],[103.815,1.27],[103.8164,1.2695],[103.83,1.265],[103.8356,1.2646],[103.8375,1.2659],[103.8384,1.2694],[103.8295,1.2828],[103.8333,1.2898],[103.8255,1.2985],[103.8203,1.3011],[103.814,1.2983],[103.8139,1.2982],[103.8129,1.2978],[103.801,1.2898],[103.801,1.2728],[103.8093,1.2711]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"RIVER VALLEY","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8415,1.3038],[103.8255,1.2985],[103.8333,1.2898],[103.8372,1.2908],[103.8409,1.2959],[103.8415,1.3038]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"OUTRAM","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8448,1.2833],[103.8372,1.2908],[103.8333,1.2898],[103.8295,1.2828],[103.8384,1.2694],[103.8448,1.2833]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"SINGAPORE RIVER","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8504,1.2918],[103.8409,1.2959],[103.8372,1.2908],[103.8448,1.2833],[103.8457,1.2836],[103.8505,1.2903],[103.8504,1.2918]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"MUSEUM","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8435,1.3053],[103.8416,1.3041],[103.8415,1.3038],[103.8409,1.2959],[103.8504,1.2918],[103.8551,1.2981],[103.8435,1.3053]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"DOWNTOWN CORE","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8505,1.2903],[103.8457,1.2836],[103.8448,1.2833],[103.8384,1.2694],[103.8557,1.2745],[103.8571,1.2787],[103.8617,1.2829],[103.8636,1.2836],[103.8645,1.299],[103.8635,1.2999],[103.8551,1.2981],[103.8504,1.2918],[103.8505,1.2903]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"ROCHOR","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8494,1.3164],[103.8435,1.3053],[103.8551,1.2981],[103.8635,1.2999],[103.8626,1.3056],[103.8559,1.3138],[103.8494,1.3164]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"KALLANG","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8559,1.3138],[103.8626,1.3056],[103.8635,1.2999],[103.8645,1.299],[103.88,1.299],[103.8822,1.3044],[103.8725,1.3155],[103.8725,1.3256],[103.8729,1.3268],[103.8619,1.3302],[103.855,1.3276],[103.8493,1.319],[103.8491,1.3173],[103.8494,1.3164],[103.8559,1.3138]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"GEYLANG","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.9063,1.3182],[103.8946,1.3391],[103.8819,1.3335],[103.8804,1.3317],[103.8729,1.3268],[103.8725,1.3256],[103.8725,1.3155],[103.8822,1.3044],[103.8874,1.3086],[103.8972,1.3097],[103.9063,1.3182]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"MARINE PARADE","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8994,1.2952],[103.902,1.296],[103.925,1.301],[103.928,1.3021],[103.9162,1.3149],[103.9063,1.3182],[103.8972,1.3097],[103.8874,1.3086],[103.8822,1.3044],[103.88,1.299],[103.8847,1.2896],[103.886,1.291],[103.8994,1.2952]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"MARINA EAST","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8744,1.2778],[103.876,1.28],[103.8847,1.2896],[103.88,1.299],[103.8645,1.299],[103.8636,1.2836],[103.8744,1.2778]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"MARINA SOUTH","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8616,1.2616],[103.866,1.266],[103.8744,1.2778],[103.8636,1.2836],[103.8617,1.2829],[103.8571,1.2787],[103.8557,1.2745],[103.8616,1.2616]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"STRAITS VIEW","REGION_N":"CENTRAL REGION"},"geometry":{"type":"Polygon","coordinates":[[[103.8401,1.2643],[103.845,1.264],[103.86,1.26],[103.8616,1.2616],[103.8557,1.2745],[103.8384,1.2694],[103.8375,1.2659],[103.8401,1.2643]]]}},
+	{"type":"Feature","properties":{"PLN_AREA_N":"SOUTHERN ISLANDS","REGION_N":"CENTRAL REGION"},"geometry":{"type":"MultiPolygon","coordinates":[[[[103.8236,1.2407],[103.8373,1.2435],[103.84,1.244],[103.85,1.25],[103.836,1.26],[103.815,1.26],[103.805,1.246],[103.82,1.24],[103.8236,1.2407]]],[[[103.8356,1.2646],[103.8401,1.2643],[103.8375,1.2659],[103.8356,1.2646]]],[[[103.85,1.218],[103.858,1.216],[103.862,1.222],[103.854,1.226],[103.85,1.218]]]]}}
+]}`
@@ -0,0 +1,172 @@
+package geojson_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/geojson"
+)
+
+func TestPointInPolygon(t *testing.T) {
+	// 10x10 square with a 2x2 hole in the middle
+	polygon := [][][]float64{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}},
+	}
+	tests := []struct {
+		x, y     float64
+		expected bool
+	}{
+		{1, 1, true},
+		{9.9, 5, true},
+		{5, 5, false},
+		{-1, 5, false},
+		{5, 11, false},
+	}
+	for _, test := range tests {
+		if result := geojson.PointInPolygon(test.x, test.y, polygon); result != test.expected {
+			t.Errorf("PointInPolygon(%v, %v): expected %v, got %v", test.x, test.y, test.expected, result)
+		}
+	}
+}
+
+func loadBoundaries(t *testing.T) *geojson.Boundaries {
+	f, err := os.Open("./testdata/boundaries.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	b, err := geojson.LoadBoundaries(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestBoundaries_RegionAt(t *testing.T) {
+	b := loadBoundaries(t)
+	tests := []struct {
+		name                string
+		longitude, latitude float64
+		region              string
+		planningArea        string
+	}{
+		{"Changi Airport", 103.989, 1.359, "east", "CHANGI"},
+		{"Woodlands Checkpoint", 103.769, 1.445, "north", "WOODLANDS"},
+		{"Raffles Place", 103.851, 1.284, "south", "DOWNTOWN CORE"},
+		{"Jurong Point", 103.706, 1.340, "west", "JURONG WEST"},
+		{"Compass One", 103.895, 1.392, "east", "SENGKANG"},
+		{"Sentosa", 103.83, 1.25, "south", "SOUTHERN ISLANDS"},
+		{"St John's Island", 103.855, 1.225, "south", "SOUTHERN ISLANDS"},
+	}
+	for _, test := range tests {
+		area := b.PlanningAreaAt(test.longitude, test.latitude)
+		if area == nil {
+			t.Errorf("%v: expected a planning area, got nil", test.name)
+			continue
+		}
+		if area.Name != test.planningArea {
+			t.Errorf("%v: expected planning area %v, got %v", test.name, test.planningArea, area.Name)
+		}
+		region := b.RegionAt(test.longitude, test.latitude)
+		if region == nil || region.Name != test.region {
+			t.Errorf("%v: expected region %v, got %v", test.name, test.region, region)
+		}
+	}
+
+	// Johor Bahru is outside of Singapore
+	if area := b.PlanningAreaAt(103.76, 1.49); area != nil {
+		t.Errorf("expected no planning area outside of Singapore, got %v", area.Name)
+	}
+	if region := b.Region("South"); region == nil || len(region.MultiPolygon) != 3 {
+		t.Errorf("expected the south region to be made up of 3 polygons, got %v", region)
+	}
+}
+
+func TestDefaultBoundaries(t *testing.T) {
+	b := geojson.DefaultBoundaries
+	if len(b.PlanningAreas) != len(geojson.PlanningAreaRegions) {
+		t.Errorf("expected %v planning areas, got %v", len(geojson.PlanningAreaRegions), len(b.PlanningAreas))
+	}
+	if len(b.Regions) != 5 {
+		t.Errorf("expected 5 regions, got %v", len(b.Regions))
+	}
+
+	tests := []struct {
+		name                string
+		longitude, latitude float64
+		region              string
+		planningArea        string
+	}{
+		{"Raffles Place", 103.851, 1.284, "south", "DOWNTOWN CORE"},
+		{"Tiong Bahru", 103.827, 1.286, "south", "BUKIT MERAH"},
+		{"Sentosa", 103.830, 1.250, "south", "SOUTHERN ISLANDS"},
+		{"Compass One", 103.895, 1.392, "east", "SENGKANG"},
+		{"Punggol Waterway", 103.905, 1.405, "east", "PUNGGOL"},
+		{"Ang Mo Kio Hub", 103.848, 1.370, "central", "ANG MO KIO"},
+		{"Changi Airport", 103.989, 1.359, "east", "CHANGI"},
+		{"Woodlands Checkpoint", 103.769, 1.445, "north", "WOODLANDS"},
+		{"Jurong Point", 103.706, 1.340, "west", "JURONG WEST"},
+		{"Pulau Ubin", 103.960, 1.410, "east", "NORTH-EASTERN ISLANDS"},
+	}
+	for _, test := range tests {
+		area := b.PlanningAreaAt(test.longitude, test.latitude)
+		if area == nil || area.Name != test.planningArea || area.Region != test.region {
+			t.Errorf("%v: expected %v in the %v region, got %+v", test.name, test.planningArea, test.region, area)
+			continue
+		}
+		if region := b.RegionAt(test.longitude, test.latitude); region == nil || region.Name != test.region {
+			t.Errorf("%v: expected region %v, got %v", test.name, test.region, region)
+		}
+	}
+	if area := b.PlanningAreaAt(103.76, 1.49); area != nil {
+		t.Errorf("expected no planning area in Johor Bahru, got %v", area.Name)
+	}
+}
+
+func TestLoadBoundaries_Regions(t *testing.T) {
+	tests := []struct {
+		properties string
+		region     string
+		err        bool
+	}{
+		{`{"PLN_AREA_N": "BISHAN", "REGION_N": "CENTRAL REGION"}`, "central", false},
+		{`{"PLN_AREA_N": "BEDOK", "REGION_N": "EAST REGION"}`, "east", false},
+		{`{"PLN_AREA_N": "YISHUN", "REGION_N": "NORTH REGION"}`, "north", false},
+		{`{"PLN_AREA_N": "PUNGGOL", "REGION_N": "NORTH-EAST REGION"}`, "east", false},
+		{`{"PLN_AREA_N": "SELETAR", "REGION_N": "NORTH-EAST REGION"}`, "north", false},
+		{`{"PLN_AREA_N": "CLEMENTI", "REGION_N": "WEST REGION"}`, "south", false},
+		{`{"PLN_AREA_N": "DOWNTOWN CORE", "REGION_N": "CENTRAL REGION"}`, "south", false},
+		{`{"name": "Bukit Merah"}`, "south", false},
+		{`{"name": "Bukit Merah", "region": "Central"}`, "central", false},
+		{`{"name": "Harbourfront", "region": "south"}`, "south", false},
+		{`{"name": "Harbourfront"}`, "", true},
+		{`{"REGION_N": "CENTRAL REGION"}`, "", true},
+		{`{"name": "Bishan", "region": "central region"}`, "", true},
+	}
+	for _, test := range tests {
+		b, err := geojson.LoadBoundaries(strings.NewReader(`{
+			"type": "FeatureCollection",
+			"features": [{
+				"type": "Feature",
+				"properties": ` + test.properties + `,
+				"geometry": {"type": "Polygon", "coordinates": [[[103.83, 1.34], [103.85, 1.34], [103.85, 1.36], [103.83, 1.36], [103.83, 1.34]]]}
+			}]
+		}`))
+		if test.err {
+			if err == nil {
+				t.Errorf("%v: expected an error", test.properties)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", test.properties, err)
+			continue
+		}
+		area := b.PlanningAreaAt(103.84, 1.35)
+		if area == nil || area.Region != test.region {
+			t.Errorf("%v: expected region %q, got %v", test.properties, test.region, area)
+		}
+	}
+}
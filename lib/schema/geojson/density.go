@@ -0,0 +1,164 @@
+package geojson
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// metresPerDegree is the length of one degree of latitude (and of longitude at the equator) in metres
+const metresPerDegree = 111320.0
+
+// Bucket is a group of positions aggregated into a cell or area
+type Bucket struct {
+	Key          string
+	Count        int
+	Centroid     []float64
+	MultiPolygon [][][][]float64
+}
+
+// Feature returns the bucket as a GeoJSON Feature, with the count and centroid as properties
+func (b *Bucket) Feature() map[string]interface{} {
+	boundary := Boundary{
+		Name:         b.Key,
+		MultiPolygon: b.MultiPolygon,
+	}
+	feature := boundary.Feature()
+	feature["properties"] = map[string]interface{}{
+		"key":      b.Key,
+		"count":    b.Count,
+		"centroid": []interface{}{b.Centroid[0], b.Centroid[1]},
+	}
+	return feature
+}
+
+// FeatureCollection returns the buckets as a GeoJSON FeatureCollection
+func FeatureCollection(buckets []*Bucket) map[string]interface{} {
+	features := []interface{}{}
+	for _, bucket := range buckets {
+		features = append(features, bucket.Feature())
+	}
+	return map[string]interface{}{
+		"type": "FeatureCollection",
+		"crs": map[string]interface{}{
+			"type": "name",
+			"properties": map[string]interface{}{
+				"name": "urn:ogc:def:crs:OGC:1.3:CRS84",
+			},
+		},
+		"features": features,
+	}
+}
+
+// bucketer collects positions into buckets by key, tracking a running centroid
+type bucketer struct {
+	buckets map[string]*Bucket
+	sums    map[string][2]float64
+}
+
+func newBucketer() *bucketer {
+	return &bucketer{
+		buckets: map[string]*Bucket{},
+		sums:    map[string][2]float64{},
+	}
+}
+
+func (b *bucketer) add(key string, position []float64, polygon func() [][][][]float64) {
+	bucket, ok := b.buckets[key]
+	if !ok {
+		bucket = &Bucket{
+			Key:          key,
+			MultiPolygon: polygon(),
+		}
+		b.buckets[key] = bucket
+	}
+	bucket.Count++
+	sum := b.sums[key]
+	b.sums[key] = [2]float64{sum[0] + position[0], sum[1] + position[1]}
+}
+
+// list returns the buckets sorted by count (highest first), then key
+func (b *bucketer) list() []*Bucket {
+	buckets := []*Bucket{}
+	for key, bucket := range b.buckets {
+		sum := b.sums[key]
+		bucket.Centroid = []float64{sum[0] / float64(bucket.Count), sum[1] / float64(bucket.Count)}
+		buckets = append(buckets, bucket)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Count != buckets[j].Count {
+			return buckets[i].Count > buckets[j].Count
+		}
+		return buckets[i].Key < buckets[j].Key
+	})
+	return buckets
+}
+
+func boxPolygon(bbox []float64) [][][][]float64 {
+	return [][][][]float64{{{
+		{bbox[0], bbox[1]},
+		{bbox[2], bbox[1]},
+		{bbox[2], bbox[3]},
+		{bbox[0], bbox[3]},
+		{bbox[0], bbox[1]},
+	}}}
+}
+
+// GeohashBuckets aggregates [longitude, latitude] positions by geohash of the given precision
+func GeohashBuckets(positions [][]float64, precision int) []*Bucket {
+	b := newBucketer()
+	for _, position := range positions {
+		if len(position) < 2 {
+			continue
+		}
+		hash := EncodeGeohash(position[0], position[1], precision)
+		b.add(hash, position, func() [][][][]float64 {
+			return boxPolygon(GeohashBoundingBox(hash))
+		})
+	}
+	return b.list()
+}
+
+// GridBuckets aggregates [longitude, latitude] positions into square cells of roughly cellSize metres.
+// Rows are cellSize metres of latitude; each row is split into columns of cellSize metres at the row's latitude.
+func GridBuckets(positions [][]float64, cellSize float64) []*Bucket {
+	b := newBucketer()
+	if cellSize <= 0 {
+		return b.list()
+	}
+	dLat := cellSize / metresPerDegree
+	for _, position := range positions {
+		if len(position) < 2 {
+			continue
+		}
+		row := math.Floor(position[1] / dLat)
+		minLat := row * dLat
+		dLng := cellSize / (metresPerDegree * math.Cos((minLat+dLat/2)*math.Pi/180))
+		col := math.Floor(position[0] / dLng)
+		minLng := col * dLng
+		b.add(fmt.Sprintf("%d:%d", int64(row), int64(col)), position, func() [][][][]float64 {
+			return boxPolygon([]float64{minLng, minLat, minLng + dLng, minLat + dLat})
+		})
+	}
+	return b.list()
+}
+
+// BoundaryBuckets aggregates [longitude, latitude] positions by the boundary they fall in.
+// Positions outside all boundaries are left out.
+func BoundaryBuckets(positions [][]float64, boundaries []*Boundary) []*Bucket {
+	b := newBucketer()
+	for _, position := range positions {
+		if len(position) < 2 {
+			continue
+		}
+		for _, boundary := range boundaries {
+			if boundary.Contains(position[0], position[1]) {
+				b.add(boundary.Name, position, func() [][][][]float64 {
+					return boundary.MultiPolygon
+				})
+				break
+			}
+		}
+	}
+	return b.list()
+}
@@ -0,0 +1,48 @@
+package geojson_test
+
+import (
+	"testing"
+
+	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/geojson"
+)
+
+func TestEncodeGeohash(t *testing.T) {
+	// reference value from https://en.wikipedia.org/wiki/Geohash
+	if hash := geojson.EncodeGeohash(10.40744, 57.64911, 11); hash != "u4pruydqqvj" {
+		t.Fatalf("expected u4pruydqqvj, got %v", hash)
+	}
+	bbox := geojson.GeohashBoundingBox(geojson.EncodeGeohash(103.8545, 1.2868, 7))
+	if !(bbox[0] <= 103.8545 && 103.8545 <= bbox[2] && bbox[1] <= 1.2868 && 1.2868 <= bbox[3]) {
+		t.Fatalf("expected bounding box %v to contain the encoded position", bbox)
+	}
+}
+
+func TestGeohashBuckets(t *testing.T) {
+	positions := [][]float64{
+		{103.8545, 1.2868},
+		{103.8546, 1.2869},
+		{103.9890, 1.3590},
+	}
+	buckets := geojson.GeohashBuckets(positions, 6)
+	if len(buckets) != 2 {
+		t.Fatalf("expected 2 buckets, got %v", len(buckets))
+	}
+	if buckets[0].Count != 2 || buckets[1].Count != 1 {
+		t.Fatalf("expected buckets sorted by count, got %v and %v", buckets[0].Count, buckets[1].Count)
+	}
+	if buckets[0].Centroid[0] != 103.85455 {
+		t.Fatalf("expected centroid longitude 103.85455, got %v", buckets[0].Centroid[0])
+	}
+}
+
+func TestGridBuckets(t *testing.T) {
+	positions := [][]float64{
+		{103.8545, 1.2868},
+		{103.8546, 1.2869},
+		{103.9890, 1.3590},
+	}
+	buckets := geojson.GridBuckets(positions, 1000)
+	if len(buckets) != 2 || buckets[0].Count != 2 {
+		t.Fatalf("expected 2 buckets with the first holding 2 positions, got %v", buckets)
+	}
+}
@@ -0,0 +1,83 @@
+package geojson
+
+import (
+	"strings"
+)
+
+const geohashBase32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// EncodeGeohash returns the geohash of a position with the given number of characters (1-12)
+func EncodeGeohash(longitude, latitude float64, precision int) string {
+	if precision < 1 {
+		precision = 1
+	}
+	if precision > 12 {
+		precision = 12
+	}
+	minLng, maxLng := -180.0, 180.0
+	minLat, maxLat := -90.0, 90.0
+
+	hash := make([]byte, 0, precision)
+	bit, ch := 0, 0
+	evenBit := true
+	for len(hash) < precision {
+		if evenBit {
+			mid := (minLng + maxLng) / 2
+			if longitude >= mid {
+				ch = ch<<1 | 1
+				minLng = mid
+			} else {
+				ch = ch << 1
+				maxLng = mid
+			}
+		} else {
+			mid := (minLat + maxLat) / 2
+			if latitude >= mid {
+				ch = ch<<1 | 1
+				minLat = mid
+			} else {
+				ch = ch << 1
+				maxLat = mid
+			}
+		}
+		evenBit = !evenBit
+		if bit++; bit == 5 {
+			hash = append(hash, geohashBase32[ch])
+			bit, ch = 0, 0
+		}
+	}
+	return string(hash)
+}
+
+// GeohashBoundingBox returns the [minLongitude, minLatitude, maxLongitude, maxLatitude] cell of a geohash
+func GeohashBoundingBox(hash string) []float64 {
+	minLng, maxLng := -180.0, 180.0
+	minLat, maxLat := -90.0, 90.0
+	evenBit := true
+	for _, c := range hash {
+		idx := strings.IndexRune(geohashBase32, c)
+		if idx < 0 {
+			return nil
+		}
+		for n := 4; n >= 0; n-- {
+			bitN := idx >> uint(n) & 1
+			if evenBit {
+				mid := (minLng + maxLng) / 2
+				if bitN == 1 {
+					minLng = mid
+				} else {
+					maxLng = mid
+				}
+			} else {
+				mid := (minLat + maxLat) / 2
+				if bitN == 1 {
+					minLat = mid
+				} else {
+					maxLat = mid
+				}
+			}
+			evenBit = !evenBit
+		}
+	}
+	return []float64{minLng, minLat, maxLng, maxLat}
+}
@@ -24,6 +24,8 @@ var GeometryCollectionObject *graphql.Object
 var FeatureObject *graphql.Object
 var FeatureCollectionObject *graphql.Object
 
+var LineStringInputObject *graphql.InputObject
+
 func init() {
 
 	TypeEnum = graphql.NewEnum(graphql.EnumConfig{
@@ -450,6 +452,19 @@ func init() {
 		},
 	})
 
+	LineStringInputObject = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "GeoJSONLineStringInput",
+		Description: "GeoJSON LineString Object, as an input",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"type": &graphql.InputObjectFieldConfig{
+				Type: graphql.NewNonNull(TypeEnum),
+			},
+			"coordinates": &graphql.InputObjectFieldConfig{
+				Type: graphql.NewNonNull(CoordinatesScalar),
+			},
+		},
+	})
+
 }
 
 func parseCoordinates(valueAST ast.Value) interface{} {
@@ -0,0 +1,44 @@
+package geojson
+
+import (
+	"errors"
+)
+
+// LineStringPositions returns the [x, y] positions of a GeoJSONLineStringInput argument value
+func LineStringPositions(value interface{}) ([][]float64, error) {
+	input, _ := value.(map[string]interface{})
+	if ttype, _ := input["type"].(string); ttype != "LineString" {
+		return nil, errors.New("expected a GeoJSON LineString")
+	}
+	coordinates, _ := input["coordinates"].([]interface{})
+	if len(coordinates) < 2 {
+		return nil, errors.New("a GeoJSON LineString must have at least two positions")
+	}
+	positions := [][]float64{}
+	for _, c := range coordinates {
+		position, _ := c.([]interface{})
+		if len(position) < 2 {
+			return nil, errors.New("a GeoJSON position must have at least two elements")
+		}
+		x, okX := toFloat64(position[0])
+		y, okY := toFloat64(position[1])
+		if !okX || !okY {
+			return nil, errors.New("a GeoJSON position must be made up of numbers")
+		}
+		positions = append(positions, []float64{x, y})
+	}
+	return positions, nil
+}
+
+// toFloat64 converts a coordinate value, which may have been coerced to float32 by CoordinatesScalar
+func toFloat64(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, true
+	case float32:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	}
+	return 0, false
+}
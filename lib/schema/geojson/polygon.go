@@ -0,0 +1,75 @@
+package geojson
+
+// PointInRing returns true if the position (x, y) lies inside the linear ring, using the even-odd rule.
+// Positions are in [x, y] (longitude, latitude) order; the ring may or may not be explicitly closed.
+func PointInRing(x, y float64, ring [][]float64) bool {
+	inside := false
+	n := len(ring)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		if len(ring[i]) < 2 || len(ring[j]) < 2 {
+			continue
+		}
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// PointInPolygon returns true if the position (x, y) lies inside the polygon.
+// The first ring is the exterior ring, any subsequent rings are holes.
+func PointInPolygon(x, y float64, polygon [][][]float64) bool {
+	if len(polygon) == 0 || !PointInRing(x, y, polygon[0]) {
+		return false
+	}
+	for _, hole := range polygon[1:] {
+		if PointInRing(x, y, hole) {
+			return false
+		}
+	}
+	return true
+}
+
+// PointInMultiPolygon returns true if the position (x, y) lies inside any of the polygons
+func PointInMultiPolygon(x, y float64, multiPolygon [][][][]float64) bool {
+	for _, polygon := range multiPolygon {
+		if PointInPolygon(x, y, polygon) {
+			return true
+		}
+	}
+	return false
+}
+
+// BoundingBox returns the [minX, minY, maxX, maxY] bounding box of a multi-polygon
+func BoundingBox(multiPolygon [][][][]float64) []float64 {
+	bbox := []float64{}
+	for _, polygon := range multiPolygon {
+		for _, ring := range polygon {
+			for _, position := range ring {
+				if len(position) < 2 {
+					continue
+				}
+				x, y := position[0], position[1]
+				if len(bbox) == 0 {
+					bbox = []float64{x, y, x, y}
+					continue
+				}
+				if x < bbox[0] {
+					bbox[0] = x
+				}
+				if y < bbox[1] {
+					bbox[1] = y
+				}
+				if x > bbox[2] {
+					bbox[2] = x
+				}
+				if y > bbox[3] {
+					bbox[3] = y
+				}
+			}
+		}
+	}
+	return bbox
+}
@@ -0,0 +1,72 @@
+package schema
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/geojson"
+)
+
+type regionFor struct {
+	Region       string `json:"region"`
+	PlanningArea string `json:"planning_area"`
+}
+
+var regionForObject = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "RegionFor",
+	Description: "Region and planning area that a location falls in",
+	Fields: graphql.Fields{
+		"region": &graphql.Field{
+			Description: "PSI/PM2.5 region: north, south, east, west or central",
+			Type:        graphql.NewNonNull(graphql.String),
+		},
+		"planning_area": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"region_boundary": &graphql.Field{
+			Type: geojson.GeoJSONInterface,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				r, _ := p.Source.(regionFor)
+				if boundary := geojson.DefaultBoundaries.Region(r.Region); boundary != nil {
+					return boundary.Feature(), nil
+				}
+				return nil, nil
+			},
+		},
+		"planning_area_boundary": &graphql.Field{
+			Type: geojson.GeoJSONInterface,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				r, _ := p.Source.(regionFor)
+				if boundary := geojson.DefaultBoundaries.PlanningArea(r.PlanningArea); boundary != nil {
+					return boundary.Feature(), nil
+				}
+				return nil, nil
+			},
+		},
+	},
+})
+
+var regionForField = &graphql.Field{
+	Name:        "Region For",
+	Description: "Looks up the region and planning area for a location. Returns null for locations outside Singapore.",
+	Type:        regionForObject,
+	Args: graphql.FieldConfigArgument{
+		"latitude": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.Float),
+		},
+		"longitude": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.Float),
+		},
+	},
+	Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		latitude, _ := p.Args["latitude"].(float64)
+		longitude, _ := p.Args["longitude"].(float64)
+
+		area := geojson.DefaultBoundaries.PlanningAreaAt(longitude, latitude)
+		if area == nil {
+			return nil, nil
+		}
+		return regionFor{
+			Region:       area.Region,
+			PlanningArea: area.Name,
+		}, nil
+	},
+}
@@ -28,6 +28,8 @@ func init() {
 					return map[string]interface{}{}, nil
 				},
 			},
+			"weather_at": environment.WeatherAtField(),
+			"region_for": regionForField,
 		},
 	})
 	var err error
@@ -0,0 +1,177 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/schema"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// snapshotFile lists every field and argument reachable from the root query, as of the last schema change.
+// Regenerate it with UPDATE_SCHEMA_SNAPSHOT=1 go test ./lib/schema/ after adding an entry to CHANGELOG.md.
+const snapshotFile = "schema_snapshot.txt"
+
+const introspectionQuery = `
+	fragment TypeRef on __Type {
+		kind
+		name
+		ofType { kind name ofType { kind name ofType { kind name } } }
+	}
+	{
+		__schema {
+			queryType { name }
+			types {
+				name
+				fields(includeDeprecated: true) {
+					name
+					isDeprecated
+					args { name type { ...TypeRef } }
+					type { ...TypeRef }
+				}
+			}
+		}
+	}
+`
+
+type typeRef struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	OfType *typeRef `json:"ofType"`
+}
+
+func (t *typeRef) String() string {
+	switch t.Kind {
+	case "NON_NULL":
+		return t.OfType.String() + "!"
+	case "LIST":
+		return "[" + t.OfType.String() + "]"
+	}
+	return t.Name
+}
+
+func (t *typeRef) namedType() string {
+	if t.OfType != nil {
+		return t.OfType.namedType()
+	}
+	return t.Name
+}
+
+type introspectionField struct {
+	Name         string `json:"name"`
+	IsDeprecated bool   `json:"isDeprecated"`
+	Args         []struct {
+		Name string   `json:"name"`
+		Type *typeRef `json:"type"`
+	} `json:"args"`
+	Type *typeRef `json:"type"`
+}
+
+// schemaLines returns a line for every field and argument reachable from the root query, keyed by
+// Type.field or Type.field(arg). Interface implementations are only included if reachable through a field.
+func schemaLines(t *testing.T) map[string]string {
+	result := graphql.Do(graphql.Params{
+		Schema:        schema.Root,
+		RequestString: introspectionQuery,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("introspection failed: %v", result.Errors)
+	}
+
+	var introspection struct {
+		Schema struct {
+			QueryType struct {
+				Name string `json:"name"`
+			} `json:"queryType"`
+			Types []struct {
+				Name   string               `json:"name"`
+				Fields []introspectionField `json:"fields"`
+			} `json:"types"`
+		} `json:"__schema"`
+	}
+	if err := remarshal(result.Data, &introspection); err != nil {
+		t.Fatal(err)
+	}
+	fields := map[string][]introspectionField{}
+	for _, typ := range introspection.Schema.Types {
+		fields[typ.Name] = typ.Fields
+	}
+
+	lines := map[string]string{}
+	visited := map[string]bool{}
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] || strings.HasPrefix(name, "__") {
+			return
+		}
+		visited[name] = true
+		for _, field := range fields[name] {
+			key := name + "." + field.Name
+			line := key + ": " + field.Type.String()
+			if field.IsDeprecated {
+				line += " @deprecated"
+			}
+			lines[key] = line
+			for _, arg := range field.Args {
+				argKey := fmt.Sprintf("%v(%v)", key, arg.Name)
+				lines[argKey] = argKey + ": " + arg.Type.String()
+			}
+			visit(field.Type.namedType())
+		}
+	}
+	visit(introspection.Schema.QueryType.Name)
+	return lines
+}
+
+func TestSchema_Snapshot(t *testing.T) {
+	current := schemaLines(t)
+
+	data, err := ioutil.ReadFile(snapshotFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		previous[strings.SplitN(line, ": ", 2)[0]] = line
+	}
+
+	// fields must be deprecated (and released as such) before they can be removed
+	for key, line := range previous {
+		if _, ok := current[key]; !ok && !strings.HasSuffix(line, "@deprecated") {
+			t.Errorf("%v was removed without being deprecated first", key)
+		}
+	}
+
+	sorted := []string{}
+	for _, line := range current {
+		sorted = append(sorted, line)
+	}
+	sort.Strings(sorted)
+	snapshot := strings.Join(sorted, "\n") + "\n"
+
+	if os.Getenv("UPDATE_SCHEMA_SNAPSHOT") != "" {
+		if err := ioutil.WriteFile(snapshotFile, []byte(snapshot), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	if snapshot != string(data) {
+		t.Errorf("schema has changed: add an entry to lib/schema/CHANGELOG.md and update %v with "+
+			"UPDATE_SCHEMA_SNAPSHOT=1 go test ./lib/schema/", snapshotFile)
+	}
+}
+
+func remarshal(in interface{}, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
@@ -1,7 +1,10 @@
 package transport
 
 import (
+	"errors"
+	"fmt"
 	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
 	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/common"
 	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/geojson"
 )
@@ -21,5 +24,189 @@ var taxiAvailabiltyResultObject = graphql.NewObject(graphql.ObjectConfig{
 		"result": &graphql.Field{
 			Type: geojson.GeoJSONInterface,
 		},
+		"density": taxiDensityField,
+		"nearest": taxiNearestField,
 	},
 })
+
+var taxiAvailabilityWithinInputObject = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name:        "TaxiAvailabilityWithinInput",
+	Description: "Circle around a location",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"center": &graphql.InputObjectFieldConfig{
+			Type: graphql.NewNonNull(common.LocationInputObject),
+		},
+		"radius_m": &graphql.InputObjectFieldConfig{
+			Description: "Radius in metres",
+			Type:        graphql.NewNonNull(graphql.Float),
+		},
+	},
+})
+
+var taxiDensityByEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "TaxiDensityBy",
+	Description: "How taxis are grouped into density buckets",
+	Values: graphql.EnumValueConfigMap{
+		"GEOHASH": &graphql.EnumValueConfig{
+			Value:       "GEOHASH",
+			Description: "Geohash cells; resolution is the geohash precision (1-12, default 6)",
+		},
+		"GRID": &graphql.EnumValueConfig{
+			Value:       "GRID",
+			Description: "Square grid cells; resolution is the cell size in metres (default 500)",
+		},
+		"PLANNING_AREA": &graphql.EnumValueConfig{
+			Value:       "PLANNING_AREA",
+			Description: "Planning areas; resolution is ignored",
+		},
+	},
+})
+
+var taxiDensityBucketObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "TaxiDensityBucket",
+	Fields: graphql.Fields{
+		"key": &graphql.Field{
+			Description: "Geohash, grid cell (row:column) or planning area name",
+			Type:        graphql.NewNonNull(graphql.String),
+		},
+		"count": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Int),
+		},
+		"centroid": &graphql.Field{
+			Description: "Mean location of the taxis in the bucket",
+			Type:        graphql.NewNonNull(common.LocationObject),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				bucket, _ := p.Source.(*geojson.Bucket)
+				return datagovsg.Location{
+					Longitude: bucket.Centroid[0],
+					Latitude:  bucket.Centroid[1],
+				}, nil
+			},
+		},
+		"feature": &graphql.Field{
+			Description: "Bucket polygon as a GeoJSON Feature",
+			Type:        graphql.NewNonNull(geojson.GeoJSONInterface),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				bucket, _ := p.Source.(*geojson.Bucket)
+				return bucket.Feature(), nil
+			},
+		},
+	},
+})
+
+var taxiDensityObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "TaxiDensity",
+	Fields: graphql.Fields{
+		"buckets": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(taxiDensityBucketObject))),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				buckets, _ := p.Source.([]*geojson.Bucket)
+				return buckets, nil
+			},
+		},
+		"feature_collection": &graphql.Field{
+			Description: "Buckets as a GeoJSON FeatureCollection of polygons, with key, count and centroid properties",
+			Type:        graphql.NewNonNull(geojson.GeoJSONInterface),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				buckets, _ := p.Source.([]*geojson.Bucket)
+				return geojson.FeatureCollection(buckets), nil
+			},
+		},
+	},
+})
+
+var taxiDensityField = &graphql.Field{
+	Name:        "Taxi Density",
+	Description: "Available taxis aggregated into buckets, e.g. for supply heatmaps",
+	Type:        graphql.NewNonNull(taxiDensityObject),
+	Args: graphql.FieldConfigArgument{
+		"by": &graphql.ArgumentConfig{
+			Type:         taxiDensityByEnum,
+			DefaultValue: "GEOHASH",
+		},
+		"resolution": &graphql.ArgumentConfig{
+			Type: graphql.Int,
+		},
+	},
+	Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		result, _ := p.Source.(datagovsg.TaxiAvailabilityResultGraphQL)
+		coordinates := result.Source.Coordinates()
+
+		by, _ := p.Args["by"].(string)
+		resolution, hasResolution := p.Args["resolution"].(int)
+		switch by {
+		case "GRID":
+			if !hasResolution {
+				resolution = 500
+			}
+			if resolution <= 0 {
+				return nil, errors.New("resolution must be a positive cell size in metres")
+			}
+			return geojson.GridBuckets(coordinates, float64(resolution)), nil
+		case "PLANNING_AREA":
+			return geojson.BoundaryBuckets(coordinates, geojson.DefaultBoundaries.PlanningAreas), nil
+		default:
+			if !hasResolution {
+				resolution = 6
+			}
+			if resolution < 1 || resolution > 12 {
+				return nil, errors.New("resolution must be a geohash precision between 1 and 12")
+			}
+			return geojson.GeohashBuckets(coordinates, resolution), nil
+		}
+	},
+}
+
+var taxiNeighbourObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "TaxiNeighbour",
+	Fields: graphql.Fields{
+		"location": &graphql.Field{
+			Type: graphql.NewNonNull(common.LocationObject),
+		},
+		"distance_m": &graphql.Field{
+			Description: "Distance from the given location in metres",
+			Type:        graphql.NewNonNull(graphql.Float),
+		},
+		"bearing": &graphql.Field{
+			Description: "Bearing from the given location in degrees, clockwise from north",
+			Type:        graphql.NewNonNull(graphql.Float),
+		},
+	},
+})
+
+// maxNearestTaxis caps k for taxi_availability.nearest
+const maxNearestTaxis = 100
+
+var taxiNearestField = &graphql.Field{
+	Name:        "Nearest Taxis",
+	Description: "The k available taxis nearest to a location, sorted by distance",
+	Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(taxiNeighbourObject))),
+	Args: graphql.FieldConfigArgument{
+		"latitude": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.Float),
+		},
+		"longitude": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.Float),
+		},
+		"k": &graphql.ArgumentConfig{
+			Type:         graphql.Int,
+			DefaultValue: 5,
+		},
+	},
+	Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		result, _ := p.Source.(datagovsg.TaxiAvailabilityResultGraphQL)
+
+		latitude, _ := p.Args["latitude"].(float64)
+		longitude, _ := p.Args["longitude"].(float64)
+		k, _ := p.Args["k"].(int)
+		if k < 1 || k > maxNearestTaxis {
+			return nil, fmt.Errorf("k must be between 1 and %v", maxNearestTaxis)
+		}
+
+		loc := datagovsg.Location{
+			Latitude:  latitude,
+			Longitude: longitude,
+		}
+		return result.Source.Index().Nearest(loc, k), nil
+	},
+}
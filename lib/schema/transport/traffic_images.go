@@ -1,8 +1,12 @@
 package transport
 
 import (
+	"fmt"
 	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/archive"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
 	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/common"
+	"net/url"
 )
 
 var trafficImageMetadataObject = graphql.NewObject(graphql.ObjectConfig{
@@ -20,6 +24,39 @@ var trafficImageMetadataObject = graphql.NewObject(graphql.ObjectConfig{
 	},
 })
 
+var expresswayEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "Expressway",
+	Description: "Singapore expressways (and the checkpoints and links at their ends) covered by traffic cameras",
+	Values: graphql.EnumValueConfigMap{
+		"AYE": &graphql.EnumValueConfig{Value: "AYE", Description: "Ayer Rajah Expressway, incl. Tuas Checkpoint and Sentosa Gateway"},
+		"BKE": &graphql.EnumValueConfig{Value: "BKE", Description: "Bukit Timah Expressway, incl. Woodlands Checkpoint"},
+		"CTE": &graphql.EnumValueConfig{Value: "CTE", Description: "Central Expressway"},
+		"ECP": &graphql.EnumValueConfig{Value: "ECP", Description: "East Coast Parkway"},
+		"KJE": &graphql.EnumValueConfig{Value: "KJE", Description: "Kranji Expressway"},
+		"KPE": &graphql.EnumValueConfig{Value: "KPE", Description: "Kallang-Paya Lebar Expressway"},
+		"MCE": &graphql.EnumValueConfig{Value: "MCE", Description: "Marina Coastal Expressway"},
+		"PIE": &graphql.EnumValueConfig{Value: "PIE", Description: "Pan Island Expressway"},
+		"SLE": &graphql.EnumValueConfig{Value: "SLE", Description: "Seletar Expressway"},
+		"TPE": &graphql.EnumValueConfig{Value: "TPE", Description: "Tampines Expressway"},
+	},
+})
+
+// cameraInfoField resolves a field of the camera's catalogue entry, or null if the camera is not catalogued
+func cameraInfoField(t graphql.Output, description string, get func(info *datagovsg.CameraInfo) string) *graphql.Field {
+	return &graphql.Field{
+		Description: description,
+		Type:        t,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			camera, _ := p.Source.(datagovsg.TrafficImageCamera)
+			info := datagovsg.DefaultCameraCatalogue.Camera(camera.CameraID)
+			if info == nil || get(info) == "" {
+				return nil, nil
+			}
+			return get(info), nil
+		},
+	}
+}
+
 var trafficImageCameraObject = graphql.NewObject(graphql.ObjectConfig{
 	Name: "TrafficImageCamera",
 	Fields: graphql.Fields{
@@ -41,9 +78,81 @@ var trafficImageCameraObject = graphql.NewObject(graphql.ObjectConfig{
 		"image_metadata": &graphql.Field{
 			Type: graphql.NewNonNull(trafficImageMetadataObject),
 		},
+		"image_proxy_url": &graphql.Field{
+			Description: "Path of the latest image for this camera, served through this server's image proxy. " +
+				"Optionally resized to width/height (keeping the aspect ratio if only one is given) and re-encoded with a JPEG quality.",
+			Type: graphql.NewNonNull(graphql.String),
+			Args: graphql.FieldConfigArgument{
+				"width": &graphql.ArgumentConfig{
+					Type: graphql.Int,
+				},
+				"height": &graphql.ArgumentConfig{
+					Type: graphql.Int,
+				},
+				"quality": &graphql.ArgumentConfig{
+					Type: graphql.Int,
+				},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				camera, _ := p.Source.(datagovsg.TrafficImageCamera)
+				v := url.Values{}
+				for _, name := range []string{"width", "height", "quality"} {
+					if value, ok := p.Args[name].(int); ok {
+						v.Set(name, fmt.Sprint(value))
+					}
+				}
+				path := fmt.Sprintf("/images/%v", camera.CameraID)
+				if len(v) > 0 {
+					path += "?" + v.Encode()
+				}
+				return path, nil
+			},
+		},
+		"expressway": cameraInfoField(expresswayEnum, "Expressway this camera is on",
+			func(info *datagovsg.CameraInfo) string { return info.Expressway }),
+		"road": cameraInfoField(graphql.String, "Road this camera is on",
+			func(info *datagovsg.CameraInfo) string { return info.Road }),
+		"direction": cameraInfoField(graphql.String, "Direction of traffic this camera faces, if known",
+			func(info *datagovsg.CameraInfo) string { return info.Direction }),
+		"description": cameraInfoField(graphql.String, "Description of this camera's location",
+			func(info *datagovsg.CameraInfo) string { return info.Description }),
+		"last_changed_at": &graphql.Field{
+			Description: "Timestamp of this camera's image when it last changed, as seen by the background poller. " +
+				"Null if the camera has not been polled yet.",
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				camera, _ := p.Source.(datagovsg.TrafficImageCamera)
+				health := cameraHealth(p, camera.CameraID)
+				if health == nil {
+					return nil, nil
+				}
+				return health.LastChangedAt, nil
+			},
+		},
+		"is_stale": &graphql.Field{
+			Description: "True if this camera's image has not changed for several polls, or its timestamp lags behind. " +
+				"Null if the camera has not been polled yet.",
+			Type: graphql.Boolean,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				camera, _ := p.Source.(datagovsg.TrafficImageCamera)
+				health := cameraHealth(p, camera.CameraID)
+				if health == nil {
+					return nil, nil
+				}
+				return health.IsStale, nil
+			},
+		},
 	},
 })
 
+func cameraHealth(p graphql.ResolveParams, cameraID int) *archive.CameraHealth {
+	a := archive.GetArchiveFromContext(p.Context)
+	if a == nil {
+		return nil
+	}
+	return a.Health(cameraID)
+}
+
 var trafficImagesResultItemObject = graphql.NewObject(graphql.ObjectConfig{
 	Name: "TrafficImagesResultItem",
 	Fields: graphql.Fields{
@@ -65,5 +174,12 @@ var trafficImagesResultObject = graphql.NewObject(graphql.ObjectConfig{
 		"items": &graphql.Field{
 			Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(trafficImagesResultItemObject))),
 		},
+		"camera_catalogue_version": &graphql.Field{
+			Description: "Version of the catalogue used for camera expressway, road, direction and description",
+			Type:        graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return datagovsg.DefaultCameraCatalogue.Version, nil
+			},
+		},
 	},
 })
@@ -1,11 +1,13 @@
 package transport
 
 import (
+	"errors"
 	"fmt"
 	"github.com/google/go-querystring/query"
 	"github.com/graphql-go/graphql"
 	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
 	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/common"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/geojson"
 )
 
 var transportObject *graphql.Object
@@ -25,6 +27,14 @@ func RootObject() *graphql.Object {
 					"date_time": &graphql.ArgumentConfig{
 						Type: common.DateTimeStringScalar,
 					},
+					"within": &graphql.ArgumentConfig{
+						Description: "Only return taxis within a radius of a location",
+						Type:        taxiAvailabilityWithinInputObject,
+					},
+					"bbox": &graphql.ArgumentConfig{
+						Description: "Only return taxis within a bounding box: [min longitude, min latitude, max longitude, max latitude]",
+						Type:        graphql.NewList(graphql.NewNonNull(graphql.Float)),
+					},
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 
@@ -36,7 +46,8 @@ func RootObject() *graphql.Object {
 						DateTime: dateTime,
 					})
 
-					ch := c.Get(
+					ch := c.GetContext(
+						p.Context,
 						fmt.Sprintf("https://api.data.gov.sg/v1/transport/taxi-availability?%v", v.Encode()),
 						&datagovsg.TaxiAvailabilityResult{},
 					)
@@ -45,6 +56,21 @@ func RootObject() *graphql.Object {
 						return nil, res.Err
 					}
 					resp, _ := res.Body.(*datagovsg.TaxiAvailabilityResult)
+
+					if within, ok := p.Args["within"].(map[string]interface{}); ok {
+						radius, _ := within["radius_m"].(float64)
+						resp = resp.WithinRadius(common.LocationFromInput(within["center"]), radius)
+					}
+					if bboxArg, ok := p.Args["bbox"].([]interface{}); ok {
+						if len(bboxArg) != 4 {
+							return nil, errors.New("bbox must have exactly 4 values: [min longitude, min latitude, max longitude, max latitude]")
+						}
+						bbox := [4]float64{}
+						for i, v := range bboxArg {
+							bbox[i], _ = v.(float64)
+						}
+						resp = resp.WithinBoundingBox(bbox)
+					}
 					return resp.ToGraphQL(), nil
 				},
 			},
@@ -55,30 +81,130 @@ func RootObject() *graphql.Object {
 					"date_time": &graphql.ArgumentConfig{
 						Type: common.DateTimeStringScalar,
 					},
+					"expressway": &graphql.ArgumentConfig{
+						Description: "Only return cameras on this expressway",
+						Type:        expresswayEnum,
+					},
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					resp, err := getTrafficImages(p)
+					if err != nil {
+						return nil, err
+					}
+					if expressway, ok := p.Args["expressway"].(string); ok {
+						resp = resp.FilterByExpressway(datagovsg.DefaultCameraCatalogue, expressway)
+					}
+					return resp.ToGraphQL(), nil
+				},
+			},
+			"camera": &graphql.Field{
+				Name:        "Traffic Camera",
+				Description: "Traffic camera by camera_id",
+				Type:        trafficImageCameraObject,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.Int),
+					},
+					"date_time": &graphql.ArgumentConfig{
+						Type: common.DateTimeStringScalar,
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					resp, err := getTrafficImages(p)
+					if err != nil {
+						return nil, err
+					}
+					id, _ := p.Args["id"].(int)
+					if camera := resp.CameraByID(id); camera != nil {
+						return *camera, nil
+					}
+					return nil, nil
+				},
+			},
+			"cameras": &graphql.Field{
+				Name: "Traffic Cameras",
+				Description: "Traffic cameras within radius_m of a location (nearest first), " +
+					"or within buffer_m of a route (nearest to the route first)",
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(trafficImageCameraObject))),
+				Args: graphql.FieldConfigArgument{
+					"near": &graphql.ArgumentConfig{
+						Type: common.LocationInputObject,
+					},
+					"radius_m": &graphql.ArgumentConfig{
+						Description:  "Radius around near, in metres",
+						Type:         graphql.Float,
+						DefaultValue: 1000.0,
+					},
+					"along": &graphql.ArgumentConfig{
+						Type: geojson.LineStringInputObject,
+					},
+					"buffer_m": &graphql.ArgumentConfig{
+						Description:  "Distance from along, in metres",
+						Type:         graphql.Float,
+						DefaultValue: 200.0,
+					},
+					"date_time": &graphql.ArgumentConfig{
+						Type: common.DateTimeStringScalar,
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					near, _ := p.Args["near"].(map[string]interface{})
+					along, _ := p.Args["along"].(map[string]interface{})
+					hasNear, hasAlong := near != nil, along != nil
+					if hasNear == hasAlong {
+						return nil, errors.New("cameras requires exactly one of near or along")
+					}
 
-					c := datagovsg.GetClientFromContext(p.Context)
-
-					dateTime, _ := p.Args["date_time"].(string)
+					resp, err := getTrafficImages(p)
+					if err != nil {
+						return nil, err
+					}
 
-					v, _ := query.Values(datagovsg.TrafficImagesOptions{
-						DateTime: dateTime,
-					})
+					if hasNear {
+						radius, _ := p.Args["radius_m"].(float64)
+						return resp.CamerasNear(common.LocationFromInput(near), radius), nil
+					}
 
-					ch := c.Get(
-						fmt.Sprintf("https://api.data.gov.sg/v1/transport/traffic-images?%v", v.Encode()),
-						&datagovsg.TrafficImagesResult{},
-					)
-					res := <-ch
-					if res.Err != nil {
-						return nil, res.Err
+					positions, err := geojson.LineStringPositions(along)
+					if err != nil {
+						return nil, err
 					}
-					resp, _ := res.Body.(*datagovsg.TrafficImagesResult)
-					return resp.ToGraphQL(), nil
+					route := []datagovsg.Location{}
+					for _, position := range positions {
+						route = append(route, datagovsg.Location{
+							Longitude: position[0],
+							Latitude:  position[1],
+						})
+					}
+					buffer, _ := p.Args["buffer_m"].(float64)
+					return resp.CamerasAlong(route, buffer), nil
 				},
 			},
 		},
 	})
 	return transportObject
 }
+
+// getTrafficImages fetches the traffic images for the date_time argument through the shared client
+func getTrafficImages(p graphql.ResolveParams) (*datagovsg.TrafficImagesResult, error) {
+
+	c := datagovsg.GetClientFromContext(p.Context)
+
+	dateTime, _ := p.Args["date_time"].(string)
+
+	v, _ := query.Values(datagovsg.TrafficImagesOptions{
+		DateTime: dateTime,
+	})
+
+	ch := c.GetContext(
+		p.Context,
+		fmt.Sprintf("https://api.data.gov.sg/v1/transport/traffic-images?%v", v.Encode()),
+		&datagovsg.TrafficImagesResult{},
+	)
+	res := <-ch
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	resp, _ := res.Body.(*datagovsg.TrafficImagesResult)
+	return resp, nil
+}
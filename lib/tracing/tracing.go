@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"github.com/graphql-go/graphql"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/context"
+	"strings"
+)
+
+const ServiceName = "data-gov-sg-graphql-go"
+
+var tracer = otel.Tracer("github.com/sogko/data-gov-sg-graphql-go/lib/tracing")
+
+// Setup exports spans over OTLP/HTTP to a collector at endpoint (host:port, e.g. "localhost:4318").
+// Nothing is traced if endpoint is empty. The returned function flushes pending spans and stops exporting.
+func Setup(endpoint string) (shutdown func(context.Context) error, err error) {
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := otlptracehttp.New(context.Background(),
+		otlptracehttp.WithEndpoint(endpoint),
+		otlptracehttp.WithInsecure(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// StartOperation starts the span of a GraphQL operation
+func StartOperation(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	name := "query"
+	if operationName != "" {
+		name += " " + operationName
+	}
+	return tracer.Start(ctx, name, trace.WithAttributes(
+		attribute.String("graphql.operation.type", "query"),
+		attribute.String("graphql.operation.name", operationName),
+	))
+}
+
+// EndOperation ends the span of a GraphQL operation with its result
+func EndOperation(span trace.Span, result *graphql.Result) {
+	if result != nil && result.HasErrors() {
+		messages := []string{}
+		for _, err := range result.Errors {
+			messages = append(messages, err.Message)
+		}
+		span.SetStatus(codes.Error, strings.Join(messages, "; "))
+	}
+	span.End()
+}
+
+// InstrumentSchema wraps every field resolver of the schema's object types in a span, which is passed on to the
+// resolver in p.Context so that upstream requests made through datagovsg.Client.GetContext become its children.
+// It must be called once, before the schema is used.
+func InstrumentSchema(schema *graphql.Schema) {
+	for name, t := range schema.TypeMap() {
+		obj, ok := t.(*graphql.Object)
+		if !ok || strings.HasPrefix(name, "__") {
+			continue
+		}
+		for fieldName, field := range obj.Fields() {
+			if field.Resolve == nil {
+				continue
+			}
+			field.Resolve = traceResolver(name, fieldName, field.Resolve)
+		}
+	}
+}
+
+func traceResolver(typeName string, fieldName string, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	spanName := typeName + "." + fieldName
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if p.Context == nil {
+			p.Context = context.Background()
+		}
+		ctx, span := tracer.Start(p.Context, spanName, trace.WithAttributes(
+			attribute.String("graphql.field.parent_type", typeName),
+			attribute.String("graphql.field.name", fieldName),
+		))
+		defer span.End()
+		p.Context = ctx
+		value, err := resolve(p)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return value, err
+	}
+}
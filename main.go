@@ -1,85 +1,505 @@
 package main
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
 	"github.com/graphql-go/handler"
 	"github.com/pressly/chi"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/archive"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/config"
 	"github.com/sogko/data-gov-sg-graphql-go/lib/datagovsg"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/imageproxy"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/metrics"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/persisted"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/querycost"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/resultcache"
 	"github.com/sogko/data-gov-sg-graphql-go/lib/schema"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/schema/geojson"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/tracing"
 	"github.com/unrolled/render"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/net/context"
+	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 )
 
 var R *render.Render
 var API_KEY string
+var Images *imageproxy.Proxy
+var Archive *archive.Archive
+var Poller *datagovsg.Poller
+var Cost *querycost.Analyzer
+var Queries *persisted.Resolver
+var MaxBatch int
+var Results *resultcache.Cache
 
-var IP string
-var PORT string
+// StaticMaxAge is how long GET responses that fetched nothing from data.gov.sg may be cached
+var StaticMaxAge = 5 * time.Minute
 
-func init() {
+// setup creates the server's components from its configuration
+func setup(cfg *config.Config) error {
+	API_KEY = cfg.APIKey
 
-	// Determine server IP
-	IP = os.Getenv("OPENSHIFT_GO_IP")
-	if PORT == "" {
-		PORT = os.Getenv("DATAGOVSG_IP")
+	// Planning area boundaries, replacing the embedded boundaries if a file is set
+	if cfg.Boundaries != "" {
+		f, err := os.Open(cfg.Boundaries)
+		if err != nil {
+			return err
+		}
+		geojson.DefaultBoundaries, err = geojson.LoadBoundaries(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%v: %v", cfg.Boundaries, err)
+		}
 	}
-	log.Println("IP", IP)
 
-	// Determine server PORT
-	PORT = os.Getenv("OPENSHIFT_GO_PORT")
-	if PORT == "" {
-		PORT = os.Getenv("DATAGOVSG_PORT")
+	// Export metrics of upstream requests and resolvers
+	datagovsg.DefaultObserver = metrics.UpstreamObserver{}
+	metrics.InstrumentSchema(&schema.Root)
+
+	// Trace operations, resolvers and upstream requests. Spans are only exported if a collector is configured.
+	tracing.InstrumentSchema(&schema.Root)
+
+	// Traffic camera locations and directions, replacing the embedded catalogue if a file is set
+	if cfg.CameraCatalogue != "" {
+		f, err := os.Open(cfg.CameraCatalogue)
+		if err != nil {
+			return err
+		}
+		datagovsg.DefaultCameraCatalogue, err = datagovsg.LoadCameraCatalogue(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%v: %v", cfg.CameraCatalogue, err)
+		}
 	}
-	if PORT == "" {
-		PORT = "3000"
+
+	Images = imageproxy.New(API_KEY)
+
+	// Poll traffic images in the background to track camera health.
+	// Images are only archived to disk if an archive directory is set.
+	Archive = archive.New(cfg.ArchiveDir)
+	Archive.StaleAfterPolls = cfg.ArchiveStalePolls
+	Poller = datagovsg.NewPoller(API_KEY)
+	Archive.Watch(Poller, time.Minute)
+
+	// Reject queries that are too deep or would make too many upstream requests
+	Cost = &querycost.Analyzer{
+		Schema:    schema.Root,
+		Datasets:  schema.UpstreamDatasets,
+		DateArgs:  schema.DateArgs,
+		FieldCost: 1,
+		FetchCost: cfg.FetchCost,
+		Limits: querycost.Limits{
+			MaxDepth: cfg.MaxDepth,
+			MaxCost:  cfg.MaxCost,
+		},
 	}
 
-	// Get data.gov.sg API key from env vars (required)
-	API_KEY = os.Getenv("DATAGOVSG_API_KEY")
-	if API_KEY == "" {
-		panic("Set DATAGOVSG_API_KEY environment variable before running server")
+	// Automatic persisted queries, or only queries from an allowlist if one is set
+	Queries = &persisted.Resolver{
+		Cache: persisted.NewCache(cfg.PersistedQueries),
+	}
+	if cfg.Allowlist != "" {
+		f, err := os.Open(cfg.Allowlist)
+		if err != nil {
+			return err
+		}
+		Queries.Allowlist, err = persisted.LoadAllowlist(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%v: %v", cfg.Allowlist, err)
+		}
+		log.Println("Allowlist mode:", len(Queries.Allowlist), "queries")
 	}
-	log.Println("API key OK")
+
+	// Cache results until their data goes stale or the poller sees new data upstream
+	Results = resultcache.New(cfg.ResultCache)
+	Results.Watch(Poller, time.Minute)
+
+	MaxBatch = cfg.MaxBatch
 
 	R = render.New(render.Options{
 		Directory:     "views",
 		IsDevelopment: true,
 		Extensions:    []string{".html"},
 	})
+	return nil
+}
+
+// graphQLResponse is a GraphQL result with extensions
+type graphQLResponse struct {
+	*graphql.Result
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+
+	// maxAge is how long the result may be cached
+	maxAge time.Duration
+}
+
+// batchOperation is one operation of a batched request
+type batchOperation struct {
+	handler.RequestOptions
+	Extensions json.RawMessage `json:"extensions"`
+}
+
+// readBatch returns the operations of a batched request (a JSON array of operations), or nil if the request is not
+// batched. The body is left in place to be read again. Bodies over persisted.MaxBodyBytes are an error.
+func readBatch(w http.ResponseWriter, r *http.Request) ([]batchOperation, error) {
+	if r.Method != "POST" || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, persisted.MaxBodyBytes))
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(body); len(trimmed) == 0 || trimmed[0] != '[' {
+		return nil, nil
+	}
+	batch := []batchOperation{}
+	if err := json.Unmarshal(body, &batch); err != nil {
+		return nil, err
+	}
+	return batch, nil
 }
 
 func serveGraphQL(ctx context.Context, w http.ResponseWriter, r *http.Request) {
-	// get query
-	opts := handler.NewRequestOptions(r)
+	metrics.RequestsInFlight.Inc()
+	defer metrics.RequestsInFlight.Dec()
 
-	// init and store data.gov.sg client
+	// init and store data.gov.sg client, shared by all operations of a batch so that
+	// identical upstream requests are only made once. Each operation tracks what it fetched through its own view.
 	ctx = context.WithValue(ctx, "client", datagovsg.NewClient(API_KEY))
+	ctx = context.WithValue(ctx, "archive", Archive)
 
-	// execute graphql query
+	batch, err := readBatch(w, r)
+	if err != nil {
+		R.JSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	if batch == nil {
+		hash := persisted.RequestHash(r)
+		opts := handler.NewRequestOptions(r)
+		response := executeOperation(ctx, hash, opts)
+		if r.Method == "GET" {
+			serveCacheable(w, r, response)
+			return
+		}
+		R.JSON(w, http.StatusOK, response)
+		return
+	}
+
+	if len(batch) > MaxBatch {
+		R.JSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("batch has more than %d operations", MaxBatch)})
+		return
+	}
+	if err := checkBatchCost(batch); err != nil {
+		R.JSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	responses := make([]graphQLResponse, len(batch))
+	var wg sync.WaitGroup
+	for i := range batch {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i] = executeOperation(ctx, persisted.ExtensionsHash(batch[i].Extensions), &batch[i].RequestOptions)
+		}(i)
+	}
+	wg.Wait()
+	R.JSON(w, http.StatusOK, responses)
+}
+
+// checkBatchCost returns an error if the operations of a batch together exceed the cost limit, although each may be
+// within it. Operations whose query cannot be resolved are left for executeOperation to report.
+func checkBatchCost(batch []batchOperation) error {
+	analyses := []querycost.Analysis{}
+	for i := range batch {
+		query, err := Queries.Resolve(persisted.ExtensionsHash(batch[i].Extensions), batch[i].Query)
+		if err != nil {
+			continue
+		}
+		analyses = append(analyses, Cost.Analyze(query, batch[i].Variables, batch[i].OperationName))
+	}
+	sum := querycost.Sum(analyses...)
+	if Cost.Limits.MaxCost > 0 && sum.Cost > Cost.Limits.MaxCost {
+		return fmt.Errorf("batch cost %v exceeds the limit of %v (%v fields, %v upstream fetches); "+
+			"send fewer operations or request fewer fields", sum.Cost, Cost.Limits.MaxCost, sum.Fields, sum.Fetches)
+	}
+	return nil
+}
+
+// executeOperation runs a single GraphQL operation, optionally sent as a persisted query hash
+func executeOperation(ctx context.Context, hash string, opts *handler.RequestOptions) (response graphQLResponse) {
+	start := time.Now()
+	ctx, span := tracing.StartOperation(ctx, opts.OperationName)
+	client := datagovsg.GetClientFromContext(ctx).Operation()
+	ctx = context.WithValue(ctx, "client", client)
+	defer func() {
+		metrics.ObserveOperation(opts.OperationName, start, response.Result)
+		tracing.EndOperation(span, response.Result)
+	}()
+
+	// get query, or look up a persisted one
+	query, err := Queries.Resolve(hash, opts.Query)
+	if err != nil {
+		return graphQLResponse{
+			Result: &graphql.Result{Errors: []gqlerrors.FormattedError{gqlerrors.FormatError(err)}},
+		}
+	}
+
+	// estimate query cost before hitting the upstream
+	analysis := Cost.Analyze(query, opts.Variables, opts.OperationName)
+	extensions := map[string]interface{}{"cost": analysis}
+	if err := Cost.Check(analysis); err != nil {
+		return graphQLResponse{
+			Result:     &graphql.Result{Errors: []gqlerrors.FormattedError{gqlerrors.FormatError(err)}},
+			Extensions: extensions,
+		}
+	}
+
+	// serve a cached result if the data it was built from is still fresh
+	key, err := resultcache.Key(query, opts.Variables, opts.OperationName)
+	if err == nil {
+		result, maxAge, ok := Results.Get(key)
+		metrics.ObserveResultCache(ok)
+		span.SetAttributes(attribute.Bool("graphql.result_cache.hit", ok))
+		if ok {
+			return graphQLResponse{
+				Result:     result,
+				Extensions: extensions,
+				maxAge:     maxAge,
+			}
+		}
+	}
+
+	// execute graphql query, noting which datasets were already invalidated in case one updates meanwhile
+	generation := Results.Generation()
 	params := graphql.Params{
 		Schema:         schema.Root,
-		RequestString:  opts.Query,
+		RequestString:  query,
 		VariableValues: opts.Variables,
 		OperationName:  opts.OperationName,
 		Context:        ctx,
 	}
-	result := graphql.Do(params)
+	response = graphQLResponse{
+		Result:     graphql.Do(params),
+		Extensions: extensions,
+	}
+
+	// results are fresh for as long as the data they were built from
+	maxAge, ok := client.Freshness()
+	if !ok {
+		// nothing fetched from data.gov.sg, e.g. introspection
+		maxAge = StaticMaxAge
+	}
+	if len(response.Errors) > 0 {
+		maxAge = 0
+	}
+	response.maxAge = maxAge
+	if key != "" {
+		Results.Put(key, response.Result, maxAge, client.Touched(), generation)
+	}
+	return response
+}
 
-	// render result
-	R.JSON(w, http.StatusOK, result)
+// serveCacheable writes a GraphQL response with an ETag of its content, honouring If-None-Match, and a Cache-Control
+// max-age of the time left before the upstream data it was built from goes stale
+func serveCacheable(w http.ResponseWriter, r *http.Request, response graphQLResponse) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		R.JSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+
+	if response.maxAge > 0 {
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(response.maxAge.Seconds())))
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+
+	sum := sha256.Sum256(body)
+	etag := fmt.Sprintf(`"%v"`, hex.EncodeToString(sum[:]))
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
 }
+
+func serveImage(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	cameraID, err := strconv.Atoi(chi.URLParam(ctx, "cameraID"))
+	if err != nil {
+		R.JSON(w, http.StatusBadRequest, map[string]string{"error": "camera_id must be a number"})
+		return
+	}
+
+	// optional resizing and re-encoding
+	opts := imageproxy.Options{}
+	for name, value := range map[string]*int{
+		"width":   &opts.Width,
+		"height":  &opts.Height,
+		"quality": &opts.Quality,
+	} {
+		if s := r.URL.Query().Get(name); s != "" {
+			if *value, err = strconv.Atoi(s); err != nil {
+				R.JSON(w, http.StatusBadRequest, map[string]string{"error": name + " must be a number"})
+				return
+			}
+		}
+	}
+
+	if err := opts.Validate(); err != nil {
+		R.JSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	img, err := Images.Image(cameraID, opts)
+	switch {
+	case err == imageproxy.ErrCameraNotFound:
+		R.JSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+		return
+	case err != nil:
+		log.Println("image", cameraID, err)
+		R.JSON(w, http.StatusBadGateway, map[string]string{"error": err.Error()})
+		return
+	}
+	imageproxy.ServeImage(w, r, img, Images.CamerasTTL)
+}
+
+// stopping is set to 1 once the server starts shutting down
+var stopping int32
+
+func serveHealthz(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	R.JSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// serveReadyz reports the outcome of the latest poll of each upstream endpoint. The server is not ready while it
+// is starting up or shutting down, or if no endpoint can be reached; it is degraded if only some can.
+func serveReadyz(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	statuses := Poller.Statuses()
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].URL < statuses[j].URL })
+	polled, ok := 0, 0
+	for _, status := range statuses {
+		if status.Polled() {
+			polled++
+		}
+		if status.OK() {
+			ok++
+		}
+	}
+
+	code, status := http.StatusOK, "ok"
+	switch {
+	case atomic.LoadInt32(&stopping) == 1:
+		code, status = http.StatusServiceUnavailable, "stopping"
+	case polled < len(statuses):
+		code, status = http.StatusServiceUnavailable, "starting"
+	case ok == 0 && len(statuses) > 0:
+		code, status = http.StatusServiceUnavailable, "unavailable"
+	case ok < len(statuses):
+		status = "degraded"
+	}
+	R.JSON(w, code, map[string]interface{}{
+		"status":   status,
+		"upstream": statuses,
+	})
+}
+
 func main() {
+	cfg, err := config.Load(os.Args[1:], os.Getenv)
+	if err == flag.ErrHelp {
+		return
+	}
+	if err != nil {
+		log.Fatalln("config:", err)
+	}
+	if cfg.PrintConfig {
+		cfg.Write(os.Stdout)
+		return
+	}
+	if cfg.File != "" {
+		log.Println("Config file", cfg.File)
+	}
+	if err := setup(cfg); err != nil {
+		log.Fatalln(err)
+	}
+	stopTracing, err := tracing.Setup(cfg.OTLPEndpoint)
+	if err != nil {
+		log.Fatalln("tracing:", err)
+	}
+
 	r := chi.NewRouter()
 
 	r.Handle("/graphql", serveGraphQL)
+	r.Get("/images/:cameraID", serveImage)
 	r.FileServer("/", http.Dir("static"))
 
-	bind := fmt.Sprintf("%s:%s", IP, PORT)
-	log.Println("Starting server at", bind)
+	r.Get("/healthz", serveHealthz)
+	r.Get("/readyz", serveReadyz)
+	r.Handle("/metrics", metrics.Handler())
+
+	Poller.Start()
+
+	server := &http.Server{
+		Addr:         net.JoinHostPort(cfg.IP, cfg.Port),
+		Handler:      r,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: time.Minute,
+		IdleTimeout:  2 * time.Minute,
+	}
+	go func() {
+		log.Println("Starting server at", server.Addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalln(err)
+		}
+	}()
+
+	// on SIGINT or SIGTERM, report that the server is stopping while load balancers stop sending it requests,
+	// then stop accepting requests and give in-flight ones time to finish
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	log.Println("Received", <-signals, "shutting down")
+	atomic.StoreInt32(&stopping, 1)
+	time.Sleep(time.Duration(cfg.ShutdownDelay) * time.Second)
 
-	http.ListenAndServe(bind, r)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownTimeout)*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Println("shutdown:", err)
+	}
+	stopped := make(chan struct{})
+	go func() {
+		Poller.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Println("shutdown: gave up waiting for the poller")
+	}
+	if err := stopTracing(ctx); err != nil {
+		log.Println("shutdown: flushing traces:", err)
+	}
+	log.Println("Stopped")
 }
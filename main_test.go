@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/config"
+	"github.com/sogko/data-gov-sg-graphql-go/lib/persisted"
+	"golang.org/x/net/context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// samples serves data.gov.sg requests from the sample responses in lib/datagovsg/sample
+type samples struct{}
+
+func (samples) RoundTrip(r *http.Request) (*http.Response, error) {
+	name := strings.NewReplacer("/", "_", "2-hour", "two_hour", "24-hour", "twenty_four_hour", "4-day", "four_day", "-", "_").
+		Replace(strings.TrimPrefix(r.URL.Path, "/v1/"))
+	data, err := ioutil.ReadFile("lib/datagovsg/sample/" + name + ".json")
+	if err != nil {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: ioutil.NopCloser(bytes.NewReader(nil))}, nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func TestMain(m *testing.M) {
+	http.DefaultTransport = samples{}
+	cfg := config.Default()
+	cfg.APIKey = "test"
+	if err := setup(cfg); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+const psiQuery = `{ environment { psi { api_info { status } } } }`
+
+func post(body string) *http.Request {
+	r := httptest.NewRequest("POST", "/graphql", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	return r
+}
+
+func TestReadBatch(t *testing.T) {
+	batch, err := readBatch(httptest.NewRecorder(), post(`[{"query": "{ a }"}, {"query": "{ b }", "operationName": "B"}]`))
+	if err != nil || len(batch) != 2 || batch[0].Query != "{ a }" || batch[1].OperationName != "B" {
+		t.Fatalf("unexpected batch %+v, %v", batch, err)
+	}
+
+	// single operations are not batches, and are left to be read again
+	r := post(` {"query": "{ a }"}`)
+	if batch, err := readBatch(httptest.NewRecorder(), r); batch != nil || err != nil {
+		t.Fatalf("expected no batch, got %+v, %v", batch, err)
+	}
+	if body, _ := ioutil.ReadAll(r.Body); string(body) != ` {"query": "{ a }"}` {
+		t.Fatalf("expected the body to be left in place, got %q", body)
+	}
+	if batch, err := readBatch(httptest.NewRecorder(), httptest.NewRequest("GET", "/graphql?query={a}", nil)); batch != nil || err != nil {
+		t.Fatalf("expected no batch for GET, got %+v, %v", batch, err)
+	}
+
+	if _, err := readBatch(httptest.NewRecorder(), post(`[{"query": }]`)); err == nil {
+		t.Fatal("expected an error for a malformed batch")
+	}
+	if _, err := readBatch(httptest.NewRecorder(), post(`[`+strings.Repeat(" ", int(persisted.MaxBodyBytes))+`]`)); err == nil {
+		t.Fatal("expected an error for a body over the limit")
+	}
+}
+
+func TestServeGraphQL_Batch(t *testing.T) {
+	w := httptest.NewRecorder()
+	serveGraphQL(context.Background(), w, post(`[{"query": "`+psiQuery+`"}, {"query": "{ nope }"}]`))
+	responses := []struct {
+		Data   interface{}   `json:"data"`
+		Errors []interface{} `json:"errors"`
+	}{}
+	if err := json.Unmarshal(w.Body.Bytes(), &responses); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("%v %v: %s", w.Code, err, w.Body)
+	}
+	if len(responses) != 2 || responses[0].Data == nil || len(responses[0].Errors) != 0 || len(responses[1].Errors) == 0 {
+		t.Fatalf("expected a result and an error, got %s", w.Body)
+	}
+
+	ops := strings.Repeat(`{"query": "{ __typename }"},`, MaxBatch+1)
+	w = httptest.NewRecorder()
+	serveGraphQL(context.Background(), w, post(`[`+strings.TrimSuffix(ops, ",")+`]`))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected a batch over the size limit to be rejected, got %v: %s", w.Code, w.Body)
+	}
+}
+
+func TestServeGraphQL_BatchCost(t *testing.T) {
+	analysis := Cost.Analyze(psiQuery, nil, "")
+	maxCost := Cost.Limits.MaxCost
+	defer func() { Cost.Limits.MaxCost = maxCost }()
+	Cost.Limits.MaxCost = analysis.Cost * 2
+
+	op := `{"query": "` + psiQuery + `"}`
+	w := httptest.NewRecorder()
+	serveGraphQL(context.Background(), w, post(`[`+op+`,`+op+`]`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected a batch at the cost limit to run, got %v: %s", w.Code, w.Body)
+	}
+
+	// each operation is within the limit, but not all three together
+	w = httptest.NewRecorder()
+	serveGraphQL(context.Background(), w, post(`[`+op+`,`+op+`,`+op+`]`))
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "batch cost") {
+		t.Fatalf("expected a batch over the cost limit to be rejected, got %v: %s", w.Code, w.Body)
+	}
+}
+
+func TestServeGraphQL_PM25Advisory(t *testing.T) {
+	w := httptest.NewRecorder()
+	serveGraphQL(context.Background(), w, post(`{"query": "{ environment { pm25 { items { readings { pm25_one_hourly { south { `+
+		`band advisory { healthy_persons } } } } } } } }"}`))
+	var response struct {
+		Data struct {
+			Environment struct {
+				PM25 struct {
+					Items []struct {
+						Readings struct {
+							PM25OneHourly struct {
+								South struct {
+									Band     string
+									Advisory *struct {
+										HealthyPersons string `json:"healthy_persons"`
+									}
+								}
+							} `json:"pm25_one_hourly"`
+						}
+					}
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || len(response.Data.Environment.PM25.Items) == 0 {
+		t.Fatalf("%v %v: %s", w.Code, err, w.Body)
+	}
+	south := response.Data.Environment.PM25.Items[0].Readings.PM25OneHourly.South
+	if south.Band != "NORMAL" || south.Advisory == nil || south.Advisory.HealthyPersons != "Normal activities" {
+		t.Fatalf("expected a normal band with its advisory, got %s", w.Body)
+	}
+}
+
+func TestServeGraphQL_WeatherAt(t *testing.T) {
+	tests := []struct {
+		name                string
+		latitude, longitude float64
+		region              string
+		psi                 float64
+	}{
+		{"Tiong Bahru", 1.2855, 103.8270, "south", 38},
+		{"Compass One", 1.3925, 103.8950, "east", 34},
+		{"Jurong Point", 1.3397, 103.7066, "west", 59},
+		// at sea, outside every boundary, so by the nearest region label
+		{"Singapore Strait", 1.2000, 104.1000, "east", 34},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		serveGraphQL(context.Background(), w, post(fmt.Sprintf(`{"query": "{ weather_at(latitude: %v, longitude: %v, `+
+			`date_time: \"2016-05-09T13:00:00+08:00\") { region psi_twenty_four_hourly } }"}`, test.latitude, test.longitude)))
+		var response struct {
+			Data struct {
+				WeatherAt struct {
+					Region string
+					PSI    float64 `json:"psi_twenty_four_hourly"`
+				} `json:"weather_at"`
+			}
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("%v: %v %v: %s", test.name, w.Code, err, w.Body)
+		}
+		if at := response.Data.WeatherAt; at.Region != test.region || at.PSI != test.psi {
+			t.Errorf("%v: expected %v with a PSI of %v, got %s", test.name, test.region, test.psi, w.Body)
+		}
+	}
+}
+
+func TestServeCacheable(t *testing.T) {
+	response := graphQLResponse{
+		Result: &graphql.Result{Data: map[string]interface{}{"a": 1}},
+		maxAge: 90 * time.Second,
+	}
+	w := httptest.NewRecorder()
+	serveCacheable(w, httptest.NewRequest("GET", "/graphql", nil), response)
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || w.Header().Get("Cache-Control") != "public, max-age=90" || etag == "" {
+		t.Fatalf("unexpected response %v %v", w.Code, w.Header())
+	}
+	if body := w.Body.String(); body != `{"data":{"a":1}}` {
+		t.Fatalf("unexpected body %v", body)
+	}
+
+	r := httptest.NewRequest("GET", "/graphql", nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	serveCacheable(w, r, response)
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Fatalf("expected 304 for a matching ETag, got %v: %s", w.Code, w.Body)
+	}
+
+	// results that must not be cached
+	response.maxAge = 0
+	w = httptest.NewRecorder()
+	serveCacheable(w, r, response)
+	if w.Header().Get("Cache-Control") != "no-cache" || w.Header().Get("ETag") != etag {
+		t.Fatalf("expected no-cache with the same ETag, got %v", w.Header())
+	}
+}